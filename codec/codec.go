@@ -0,0 +1,13 @@
+package codec
+
+// Codec marshals and unmarshals values of type T using a specific content
+// type. It is used to derive the encoders and decoders of every transport from
+// a single definition.
+type Codec[T any] interface {
+	// ContentType returns the MIME type of the encoded data.
+	ContentType() string
+	// Marshal encodes the value.
+	Marshal(v T) ([]byte, error)
+	// Unmarshal decodes the data into the value pointed to by v.
+	Unmarshal(data []byte, v *T) error
+}
@@ -0,0 +1,90 @@
+package codec_test
+
+import (
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+	"github.com/RangelReale/go-kit-typed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+type profile struct {
+	Name string `json:"name" xml:"name"`
+	Age  int    `json:"age" xml:"age"`
+}
+
+func TestCodecs(t *testing.T) {
+	tests := []struct {
+		name        string
+		codec       codec.Codec[profile]
+		contentType string
+		data        string
+	}{
+		{"json", codec.JSON[profile](), "application/json; charset=utf-8", `{"name":"john","age":30}`},
+		{"xml", codec.XML[profile](), "application/xml; charset=utf-8", `<profile><name>john</name><age>30</age></profile>`},
+	}
+
+	for _, test := range tests {
+		if want, have := test.contentType, test.codec.ContentType(); want != have {
+			t.Errorf("%s: want %s, have %s", test.name, want, have)
+		}
+
+		data, err := test.codec.Marshal(profile{Name: "john", Age: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, have := test.data, string(data); want != have {
+			t.Errorf("%s: want %s, have %s", test.name, want, have)
+		}
+
+		var p profile
+		if err := test.codec.Unmarshal(data, &p); err != nil {
+			t.Fatal(err)
+		}
+		if want, have := (profile{Name: "john", Age: 30}), p; want != have {
+			t.Errorf("%s: want %v, have %v", test.name, want, have)
+		}
+
+		var empty profile
+		if err := test.codec.Unmarshal(nil, &empty); err != nil {
+			t.Errorf("%s: empty data should decode to zero value, received %v", test.name, err)
+		}
+	}
+}
+
+func TestJSONCodecOptions(t *testing.T) {
+	var p profile
+	err := codec.JSON[profile](util.JSONDisallowUnknownFields()).Unmarshal([]byte(`{"name":"john","x":1}`), &p)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestProtoCodecs(t *testing.T) {
+	for _, c := range []codec.Codec[*pb.TestRequest]{
+		codec.Proto[*pb.TestRequest](),
+		codec.ProtoJSON[*pb.TestRequest](),
+	} {
+		data, err := c.Marshal(&pb.TestRequest{A: "a", B: 42})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var m *pb.TestRequest
+		if err := c.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(&pb.TestRequest{A: "a", B: 42}, m) {
+			t.Errorf("%s: want %v, have %v", c.ContentType(), &pb.TestRequest{A: "a", B: 42}, m)
+		}
+
+		var empty *pb.TestRequest
+		if err := c.Unmarshal(nil, &empty); err != nil {
+			t.Fatal(err)
+		}
+		if empty == nil {
+			t.Errorf("%s: expected empty message to be allocated", c.ContentType())
+		}
+	}
+}
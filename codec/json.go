@@ -0,0 +1,33 @@
+package codec
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+type jsonCodec[T any] struct {
+	options util.JSONOptions
+}
+
+// JSON returns a Codec which encodes values as JSON. Empty data decodes to the
+// zero value.
+func JSON[T any](options ...util.JSONOption) Codec[T] {
+	return jsonCodec[T]{options: util.NewJSONOptions(options...)}
+}
+
+func (c jsonCodec[T]) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (c jsonCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (c jsonCodec[T]) Unmarshal(data []byte, v *T) error {
+	if err := c.options.Unmarshal(data, v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package codec
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type protoCodec[T proto.Message] struct{}
+
+// Proto returns a Codec which encodes protobuf messages in the binary wire
+// format. T must be a pointer to a generated message type, like *pb.TestRequest.
+func Proto[T proto.Message]() Codec[T] {
+	return protoCodec[T]{}
+}
+
+func (c protoCodec[T]) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func (c protoCodec[T]) Marshal(v T) ([]byte, error) {
+	return proto.Marshal(v)
+}
+
+func (c protoCodec[T]) Unmarshal(data []byte, v *T) error {
+	return proto.Unmarshal(data, newProtoMessage(v))
+}
+
+type protoJSONCodec[T proto.Message] struct {
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+// ProtoJSON returns a Codec which encodes protobuf messages using the canonical
+// JSON mapping. T must be a pointer to a generated message type, like
+// *pb.TestRequest. Empty data decodes to an empty message.
+func ProtoJSON[T proto.Message]() Codec[T] {
+	return protoJSONCodec[T]{}
+}
+
+// ProtoJSONWithOptions is like ProtoJSON but using the passed marshal and
+// unmarshal options.
+func ProtoJSONWithOptions[T proto.Message](marshal protojson.MarshalOptions,
+	unmarshal protojson.UnmarshalOptions) Codec[T] {
+	return protoJSONCodec[T]{marshal: marshal, unmarshal: unmarshal}
+}
+
+func (c protoJSONCodec[T]) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (c protoJSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return c.marshal.Marshal(v)
+}
+
+func (c protoJSONCodec[T]) Unmarshal(data []byte, v *T) error {
+	m := newProtoMessage(v)
+	if len(data) == 0 {
+		return nil
+	}
+	return c.unmarshal.Unmarshal(data, m)
+}
+
+// newProtoMessage allocates a new message in v if it is nil, and returns it.
+func newProtoMessage[T proto.Message](v *T) proto.Message {
+	if (*v).ProtoReflect().IsValid() {
+		return *v
+	}
+	*v = (*v).ProtoReflect().New().Interface().(T)
+	return *v
+}
@@ -0,0 +1,28 @@
+package codec
+
+import (
+	"encoding/xml"
+)
+
+type xmlCodec[T any] struct{}
+
+// XML returns a Codec which encodes values as XML. Empty data decodes to the
+// zero value.
+func XML[T any]() Codec[T] {
+	return xmlCodec[T]{}
+}
+
+func (c xmlCodec[T]) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+func (c xmlCodec[T]) Marshal(v T) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+func (c xmlCodec[T]) Unmarshal(data []byte, v *T) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return xml.Unmarshal(data, v)
+}
@@ -0,0 +1,174 @@
+// Package ctxmeta propagates request metadata, like request IDs, tenants and
+// locales, between the context and the headers of the http, grpc and nats
+// transports.
+//
+// Values are stored in the context with typed keys, and a Propagator copies
+// them from the context to outgoing headers, and from incoming headers to the
+// context. Its methods return the before and after functions of each
+// transport:
+//
+//	p := ctxmeta.NewPropagator(ctxmeta.RequestID, ctxmeta.Tenant)
+//	server := httptransport.NewServer(e, dec, enc,
+//		gokithttptransport.ServerBefore(p.HTTPServerBefore()),
+//		gokithttptransport.ServerAfter(p.HTTPServerAfter()))
+//	client := grpctransport.NewClient(cc, service, method, enc, dec, reply,
+//		gokitgrpctransport.ClientBefore(p.GRPCClientBefore()))
+package ctxmeta
+
+import (
+	"context"
+	"strings"
+)
+
+// Field is a piece of metadata propagated by a Propagator. It is implemented
+// by *Key and by the fields returned by AllowList.
+type Field interface {
+	inject(ctx context.Context, c Carrier)
+	extract(ctx context.Context, c Carrier) context.Context
+}
+
+// Carrier is the set of headers of a request or response. http.Header and
+// nats.Header implement it, and MetadataCarrier adapts gRPC metadata.
+type Carrier interface {
+	Get(name string) string
+	Set(name, value string)
+}
+
+// Key is a typed context key, which is propagated in the header with its name.
+type Key[T any] struct {
+	header string
+	format func(T) string
+	parse  func(string) (T, error)
+}
+
+// NewKey returns a key propagated in the header, which formats and parses its
+// values with the passed functions. Header values which fail to parse are
+// ignored.
+func NewKey[T any](header string, format func(T) string, parse func(string) (T, error)) *Key[T] {
+	return &Key[T]{header: header, format: format, parse: parse}
+}
+
+// StringKey returns a key of string values propagated in the header.
+func StringKey(header string) *Key[string] {
+	return NewKey(header,
+		func(v string) string { return v },
+		func(s string) (string, error) { return s, nil })
+}
+
+// Predefined keys.
+var (
+	// RequestID is the ID of the request, in the X-Request-Id header.
+	RequestID = StringKey("X-Request-Id")
+	// Tenant is the tenant of the request, in the X-Tenant-Id header.
+	Tenant = StringKey("X-Tenant-Id")
+	// Locale is the locale of the request, in the Accept-Language header.
+	Locale = StringKey("Accept-Language")
+)
+
+// Header returns the name of the header of the key.
+func (k *Key[T]) Header() string {
+	return k.header
+}
+
+// With returns a copy of ctx with the value of the key.
+func (k *Key[T]) With(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// From returns the value of the key in ctx, if any.
+func (k *Key[T]) From(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k).(T)
+	return v, ok
+}
+
+func (k *Key[T]) inject(ctx context.Context, c Carrier) {
+	if v, ok := k.From(ctx); ok {
+		c.Set(k.header, k.format(v))
+	}
+}
+
+func (k *Key[T]) extract(ctx context.Context, c Carrier) context.Context {
+	s := c.Get(k.header)
+	if s == "" {
+		return ctx
+	}
+	v, err := k.parse(s)
+	if err != nil {
+		return ctx
+	}
+	return k.With(ctx, v)
+}
+
+type valuesKey struct{}
+
+// Set returns a copy of ctx with the string value of the header, which is
+// propagated if the header is in an AllowList of the Propagator. Header names
+// are case-insensitive.
+func Set(ctx context.Context, header, value string) context.Context {
+	values, _ := ctx.Value(valuesKey{}).(map[string]string)
+	ret := make(map[string]string, len(values)+1)
+	for k, v := range values {
+		ret[k] = v
+	}
+	ret[strings.ToLower(header)] = value
+	return context.WithValue(ctx, valuesKey{}, ret)
+}
+
+// Get returns the string value of the header in ctx, set by Set or extracted
+// by an AllowList.
+func Get(ctx context.Context, header string) (string, bool) {
+	values, _ := ctx.Value(valuesKey{}).(map[string]string)
+	v, ok := values[strings.ToLower(header)]
+	return v, ok
+}
+
+// AllowList returns a field which propagates the headers as string values,
+// which are read and written with Get and Set.
+func AllowList(headers ...string) Field {
+	return allowList(headers)
+}
+
+type allowList []string
+
+func (l allowList) inject(ctx context.Context, c Carrier) {
+	for _, header := range l {
+		if v, ok := Get(ctx, header); ok {
+			c.Set(header, v)
+		}
+	}
+}
+
+func (l allowList) extract(ctx context.Context, c Carrier) context.Context {
+	for _, header := range l {
+		if v := c.Get(header); v != "" {
+			ctx = Set(ctx, header, v)
+		}
+	}
+	return ctx
+}
+
+// Propagator copies fields between the context and the headers.
+type Propagator struct {
+	fields []Field
+}
+
+// NewPropagator returns a propagator of the fields.
+func NewPropagator(fields ...Field) *Propagator {
+	return &Propagator{fields: fields}
+}
+
+// Inject sets the headers of the fields which have a value in ctx.
+func (p *Propagator) Inject(ctx context.Context, c Carrier) {
+	for _, f := range p.fields {
+		f.inject(ctx, c)
+	}
+}
+
+// Extract returns a copy of ctx with the values of the fields which are in the
+// headers.
+func (p *Propagator) Extract(ctx context.Context, c Carrier) context.Context {
+	for _, f := range p.fields {
+		ctx = f.extract(ctx, c)
+	}
+	return ctx
+}
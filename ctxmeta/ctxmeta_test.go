@@ -0,0 +1,79 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/ctxmeta"
+)
+
+func TestKey(t *testing.T) {
+	retries := ctxmeta.NewKey("X-Retries", strconv.Itoa, strconv.Atoi)
+	ctx := retries.With(context.Background(), 3)
+	ctx = ctxmeta.RequestID.With(ctx, "req-1")
+
+	if v, ok := retries.From(ctx); !ok || v != 3 {
+		t.Errorf("want %d, have %d (%v)", 3, v, ok)
+	}
+	if _, ok := ctxmeta.Tenant.From(ctx); ok {
+		t.Error("want no tenant")
+	}
+	other := ctxmeta.StringKey("X-Request-Id")
+	if _, ok := other.From(ctx); ok {
+		t.Error("want keys with the same header to be distinct")
+	}
+
+	p := ctxmeta.NewPropagator(ctxmeta.RequestID, ctxmeta.Tenant, retries)
+	header := http.Header{}
+	p.Inject(ctx, header)
+	want := http.Header{"X-Request-Id": {"req-1"}, "X-Retries": {"3"}}
+	if have := header; len(want) != len(have) || have.Get("X-Request-Id") != "req-1" || have.Get("X-Retries") != "3" {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	header.Set("X-Retries", "many")
+	header.Set("X-Tenant-Id", "acme")
+	extracted := p.Extract(context.Background(), header)
+	if v, _ := ctxmeta.RequestID.From(extracted); v != "req-1" {
+		t.Errorf("want %q, have %q", "req-1", v)
+	}
+	if v, _ := ctxmeta.Tenant.From(extracted); v != "acme" {
+		t.Errorf("want %q, have %q", "acme", v)
+	}
+	if _, ok := retries.From(extracted); ok {
+		t.Error("want invalid value to be ignored")
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	ctx := ctxmeta.Set(context.Background(), "X-Feature", "beta")
+	ctx = ctxmeta.Set(ctx, "X-Secret", "hidden")
+	if v, ok := ctxmeta.Get(ctx, "x-feature"); !ok || v != "beta" {
+		t.Errorf("want %q, have %q (%v)", "beta", v, ok)
+	}
+
+	p := ctxmeta.NewPropagator(ctxmeta.AllowList("X-Feature", "X-Region"))
+	header := http.Header{}
+	p.Inject(ctx, header)
+	if want, have := "beta", header.Get("X-Feature"); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if have := header.Get("X-Secret"); have != "" {
+		t.Errorf("want no X-Secret header, have %q", have)
+	}
+
+	header.Set("X-Region", "eu")
+	header.Set("X-Secret", "leaked")
+	extracted := p.Extract(context.Background(), header)
+	if v, _ := ctxmeta.Get(extracted, "X-Region"); v != "eu" {
+		t.Errorf("want %q, have %q", "eu", v)
+	}
+	if _, ok := ctxmeta.Get(extracted, "X-Secret"); ok {
+		t.Error("want X-Secret not to be extracted")
+	}
+	if _, ok := ctxmeta.Get(ctx, "X-Region"); ok {
+		t.Error("want Set not to modify the parent context")
+	}
+}
@@ -0,0 +1,69 @@
+package ctxmeta
+
+import (
+	"context"
+
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts gRPC metadata to a Carrier. Metadata keys are
+// lowercase, so header names are matched case-insensitively.
+func MetadataCarrier(md metadata.MD) Carrier {
+	return metadataCarrier(md)
+}
+
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(name string) string {
+	if v := metadata.MD(c).Get(name); len(v) > 0 {
+		return v[len(v)-1]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(name, value string) {
+	metadata.MD(c).Set(name, value)
+}
+
+// GRPCClientBefore returns a function which sets the request metadata from
+// the context, for ClientBefore.
+func (p *Propagator) GRPCClientBefore() gokitgrpctransport.ClientRequestFunc {
+	return func(ctx context.Context, md *metadata.MD) context.Context {
+		if *md == nil {
+			*md = metadata.MD{}
+		}
+		p.Inject(ctx, metadataCarrier(*md))
+		return ctx
+	}
+}
+
+// GRPCServerBefore returns a function which sets the context from the request
+// metadata, for ServerBefore.
+func (p *Propagator) GRPCServerBefore() gokitgrpctransport.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		return p.Extract(ctx, metadataCarrier(md))
+	}
+}
+
+// GRPCServerAfter returns a function which sets the response header metadata
+// from the context, sending the values back to the client, for ServerAfter.
+func (p *Propagator) GRPCServerAfter() gokitgrpctransport.ServerResponseFunc {
+	return func(ctx context.Context, header *metadata.MD, _ *metadata.MD) context.Context {
+		if *header == nil {
+			*header = metadata.MD{}
+		}
+		p.Inject(ctx, metadataCarrier(*header))
+		return ctx
+	}
+}
+
+// GRPCClientAfter returns a function which sets the context from the response
+// header and trailer metadata, the trailer taking precedence, for
+// ClientAfter.
+func (p *Propagator) GRPCClientAfter() gokitgrpctransport.ClientResponseFunc {
+	return func(ctx context.Context, header metadata.MD, trailer metadata.MD) context.Context {
+		ctx = p.Extract(ctx, metadataCarrier(header))
+		return p.Extract(ctx, metadataCarrier(trailer))
+	}
+}
@@ -0,0 +1,40 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/ctxmeta"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGRPC(t *testing.T) {
+	p := ctxmeta.NewPropagator(ctxmeta.RequestID, ctxmeta.AllowList("X-Feature"))
+	ctx := ctxmeta.RequestID.With(context.Background(), "req-1")
+	ctx = ctxmeta.Set(ctx, "X-Feature", "beta")
+
+	var md metadata.MD
+	p.GRPCClientBefore()(ctx, &md)
+	if want, have := []string{"req-1"}, md.Get("x-request-id"); len(have) != 1 || want[0] != have[0] {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	serverCtx := p.GRPCServerBefore()(context.Background(), md)
+	if v, _ := ctxmeta.RequestID.From(serverCtx); v != "req-1" {
+		t.Errorf("want %q, have %q", "req-1", v)
+	}
+	if v, _ := ctxmeta.Get(serverCtx, "X-Feature"); v != "beta" {
+		t.Errorf("want %q, have %q", "beta", v)
+	}
+
+	var header, trailer metadata.MD
+	p.GRPCServerAfter()(serverCtx, &header, &trailer)
+	if want, have := "req-1", header.Get("x-request-id"); len(have) != 1 || want != have[0] {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	clientCtx := p.GRPCClientAfter()(context.Background(), header, metadata.Pairs("x-request-id", "req-2"))
+	if v, _ := ctxmeta.RequestID.From(clientCtx); v != "req-2" {
+		t.Errorf("want trailer %q, have %q", "req-2", v)
+	}
+}
@@ -0,0 +1,42 @@
+package ctxmeta
+
+import (
+	"context"
+	"net/http"
+
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// HTTPClientBefore returns a function which sets the request headers from the
+// context, for ClientBefore.
+func (p *Propagator) HTTPClientBefore() gokithttptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		p.Inject(ctx, r.Header)
+		return ctx
+	}
+}
+
+// HTTPServerBefore returns a function which sets the context from the request
+// headers, for ServerBefore.
+func (p *Propagator) HTTPServerBefore() gokithttptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return p.Extract(ctx, r.Header)
+	}
+}
+
+// HTTPServerAfter returns a function which sets the response headers from the
+// context, sending the values back to the client, for ServerAfter.
+func (p *Propagator) HTTPServerAfter() gokithttptransport.ServerResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter) context.Context {
+		p.Inject(ctx, w.Header())
+		return ctx
+	}
+}
+
+// HTTPClientAfter returns a function which sets the context from the response
+// headers, for ClientAfter.
+func (p *Propagator) HTTPClientAfter() gokithttptransport.ClientResponseFunc {
+	return func(ctx context.Context, r *http.Response) context.Context {
+		return p.Extract(ctx, r.Header)
+	}
+}
@@ -0,0 +1,60 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/ctxmeta"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+type request struct{}
+
+type response struct {
+	RequestID string `json:"request_id"`
+	Tenant    string `json:"tenant"`
+}
+
+func TestHTTP(t *testing.T) {
+	p := ctxmeta.NewPropagator(ctxmeta.RequestID, ctxmeta.Tenant)
+	server := httptest.NewServer(httptransport.NewServer(
+		func(ctx context.Context, _ request) (response, error) {
+			id, _ := ctxmeta.RequestID.From(ctx)
+			tenant, _ := ctxmeta.Tenant.From(ctx)
+			return response{RequestID: id, Tenant: tenant}, nil
+		},
+		httptransport.DecodeJSONRequest[request],
+		httptransport.EncodeJSONResponse[response],
+		gokithttptransport.ServerBefore(p.HTTPServerBefore()),
+		gokithttptransport.ServerAfter(p.HTTPServerAfter()),
+	))
+	defer server.Close()
+
+	tgt, _ := url.Parse(server.URL)
+	var echoedID string
+	client := httptransport.NewClient("POST", tgt,
+		httptransport.EncodeJSONRequest[request],
+		httptransport.DecodeJSONResponse[response],
+		gokithttptransport.ClientBefore(p.HTTPClientBefore()),
+		gokithttptransport.ClientAfter(p.HTTPClientAfter()),
+		gokithttptransport.ClientFinalizer(func(ctx context.Context, _ error) {
+			echoedID, _ = ctxmeta.RequestID.From(ctx)
+		}),
+	)
+
+	ctx := ctxmeta.RequestID.With(context.Background(), "req-1")
+	ctx = ctxmeta.Tenant.With(ctx, "acme")
+	resp, err := client.Endpoint()(ctx, request{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (response{RequestID: "req-1", Tenant: "acme"}), resp; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "req-1", echoedID; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
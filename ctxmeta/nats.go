@@ -0,0 +1,39 @@
+package ctxmeta
+
+import (
+	"context"
+
+	gokitnatstransport "github.com/go-kit/kit/transport/nats"
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisherBefore returns a function which sets the message headers from
+// the context, for PublisherBefore. NATS headers are case-sensitive, so the
+// header names must match on both sides.
+func (p *Propagator) NATSPublisherBefore() gokitnatstransport.RequestFunc {
+	return func(ctx context.Context, msg *nats.Msg) context.Context {
+		if msg.Header == nil {
+			msg.Header = nats.Header{}
+		}
+		p.Inject(ctx, msg.Header)
+		return ctx
+	}
+}
+
+// NATSSubscriberBefore returns a function which sets the context from the
+// message headers, for SubscriberBefore.
+func (p *Propagator) NATSSubscriberBefore() gokitnatstransport.RequestFunc {
+	return func(ctx context.Context, msg *nats.Msg) context.Context {
+		return p.Extract(ctx, msg.Header)
+	}
+}
+
+// NATSPublisherAfter returns a function which sets the context from the reply
+// headers, for PublisherAfter. The subscriber after functions can't set the
+// reply headers, so response encoders which want to send values back must
+// call Inject on the headers of the reply message.
+func (p *Propagator) NATSPublisherAfter() gokitnatstransport.PublisherResponseFunc {
+	return func(ctx context.Context, msg *nats.Msg) context.Context {
+		return p.Extract(ctx, msg.Header)
+	}
+}
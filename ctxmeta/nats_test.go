@@ -0,0 +1,36 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/ctxmeta"
+	"github.com/nats-io/nats.go"
+)
+
+func TestNATS(t *testing.T) {
+	p := ctxmeta.NewPropagator(ctxmeta.Locale)
+	ctx := ctxmeta.Locale.With(context.Background(), "pt-BR")
+
+	msg := &nats.Msg{Subject: "test"}
+	p.NATSPublisherBefore()(ctx, msg)
+	if want, have := "pt-BR", msg.Header.Get("Accept-Language"); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	serverCtx := p.NATSSubscriberBefore()(context.Background(), msg)
+	if v, _ := ctxmeta.Locale.From(serverCtx); v != "pt-BR" {
+		t.Errorf("want %q, have %q", "pt-BR", v)
+	}
+
+	reply := nats.NewMsg("reply")
+	p.Inject(serverCtx, reply.Header)
+	clientCtx := p.NATSPublisherAfter()(context.Background(), reply)
+	if v, _ := ctxmeta.Locale.From(clientCtx); v != "pt-BR" {
+		t.Errorf("want %q, have %q", "pt-BR", v)
+	}
+
+	if ctx := p.NATSSubscriberBefore()(context.Background(), &nats.Msg{}); ctx == nil {
+		t.Error("want context for message without headers")
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+// ApplyStruct wraps every non-nil endpoint field of the struct pointed to by
+// endpoints with the middleware returned by mw, which receives the field name
+// to be used as the method label. If mw returns nil the field is not changed.
+//
+//	err := middleware.ApplyStruct(&endpoints, func(method string) gokitendpoint.Middleware {
+//	    return loggingMiddleware(log.With(logger, "method", method))
+//	})
+func ApplyStruct(endpoints interface{}, mw func(method string) gokitendpoint.Middleware) error {
+	return endpoint.WrapStruct(endpoints, func(name string, e gokitendpoint.Endpoint) gokitendpoint.Endpoint {
+		m := mw(name)
+		if m == nil {
+			return nil
+		}
+		return m(e)
+	})
+}
+
+// ApplyStructAny is like ApplyStruct, but using any-typed middlewares.
+func ApplyStructAny(endpoints interface{}, mw func(method string) endpoint.Middleware[any, any]) error {
+	return endpoint.WrapStruct(endpoints, func(name string, e gokitendpoint.Endpoint) gokitendpoint.Endpoint {
+		m := mw(name)
+		if m == nil {
+			return nil
+		}
+		return gokitendpoint.Endpoint(m(endpoint.Endpoint[any, any](e)))
+	})
+}
@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/endpoint/middleware"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+type testEndpoints struct {
+	First  endpoint.Endpoint[string, string]
+	Second endpoint.Endpoint[int, int]
+}
+
+func newTestEndpoints(buf *strings.Builder) testEndpoints {
+	return testEndpoints{
+		First: strendpoint(buf),
+		Second: func(ctx context.Context, request int) (int, error) {
+			buf.WriteString(fmt.Sprintf("|endpoint-%d", request))
+			return request * 2, nil
+		},
+	}
+}
+
+func TestApplyStruct(t *testing.T) {
+	buf := strings.Builder{}
+	e := newTestEndpoints(&buf)
+
+	err := middleware.ApplyStruct(&e, func(method string) gokitendpoint.Middleware {
+		return strmiddleware(method, &buf)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.First(context.Background(), "data"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := e.Second(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 8, resp; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	expected := "|pre-First|endpoint-data|post-First|pre-Second|endpoint-4|post-Second"
+	if buf.String() != expected {
+		t.Errorf("want '%s', have '%s'", expected, buf.String())
+	}
+}
+
+func TestApplyStructAny(t *testing.T) {
+	buf := strings.Builder{}
+	e := newTestEndpoints(&buf)
+
+	err := middleware.ApplyStructAny(&e, func(method string) endpoint.Middleware[any, any] {
+		if method != "Second" {
+			return nil
+		}
+		return func(next endpoint.Endpoint[any, any]) endpoint.Endpoint[any, any] {
+			return func(ctx context.Context, request any) (any, error) {
+				buf.WriteString(fmt.Sprintf("|pre-%s", method))
+				return next(ctx, request)
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.First(context.Background(), "data"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Second(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "|endpoint-data|pre-Second|endpoint-4"
+	if buf.String() != expected {
+		t.Errorf("want '%s', have '%s'", expected, buf.String())
+	}
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// EntryInfo is the JSON representation of an entry returned by the debug
+// handler.
+type EntryInfo struct {
+	Name        string    `json:"name"`
+	Request     string    `json:"request"`
+	Response    string    `json:"response"`
+	Bindings    []Binding `json:"bindings,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Stats       Stats     `json:"stats"`
+	AvgDuration int64     `json:"avg_duration"`
+}
+
+// Info returns the JSON representation of the entry.
+func (e *Entry) Info() EntryInfo {
+	s := e.Stats()
+	return EntryInfo{
+		Name:        e.Name,
+		Request:     e.Request.String(),
+		Response:    e.Response.String(),
+		Bindings:    e.Bindings,
+		Tags:        e.Tags,
+		Stats:       s,
+		AvgDuration: int64(s.AvgDuration()),
+	}
+}
+
+// NewHandler returns a debug http.Handler which lists the registered endpoints,
+// their types, bindings, tags and call statistics as JSON. The "name" and "tag"
+// query parameters can be used to filter the list. Durations are reported in
+// nanoseconds.
+func NewHandler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var (
+			name = req.URL.Query().Get("name")
+			tag  = req.URL.Query().Get("tag")
+		)
+		list := []EntryInfo{}
+		for _, entry := range r.Entries() {
+			if name != "" && entry.Name != name {
+				continue
+			}
+			if tag != "" && !entry.HasTag(tag) {
+				continue
+			}
+			list = append(list, entry.Info())
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(list)
+	})
+}
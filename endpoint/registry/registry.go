@@ -0,0 +1,241 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+var (
+	ErrNotFound          = errors.New("endpoint not found")
+	ErrAlreadyRegistered = errors.New("endpoint already registered")
+)
+
+// Registry keeps every endpoint of a service together with its metadata.
+// It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []*Entry
+	byName  map[string]*Entry
+}
+
+// New constructs an empty Registry.
+func New() *Registry {
+	return &Registry{
+		byName: map[string]*Entry{},
+	}
+}
+
+// Binding describes how an endpoint is exposed on a transport, for example
+// Binding{Transport: "http", Address: "POST /profiles/"}.
+type Binding struct {
+	Transport string `json:"transport"`
+	Address   string `json:"address"`
+}
+
+// Entry is a registered endpoint.
+type Entry struct {
+	// Name is the unique name of the endpoint.
+	Name string
+	// Request is the type of the endpoint request.
+	Request reflect.Type
+	// Response is the type of the endpoint response.
+	Response reflect.Type
+	// Bindings lists the transports the endpoint is exposed on.
+	Bindings []Binding
+	// Tags are free-form labels.
+	Tags []string
+	// Endpoint is the non-typed version of the instrumented endpoint.
+	Endpoint gokitendpoint.Endpoint
+
+	typed interface{}
+	stats *stats
+}
+
+// HasTag returns whether the entry has the passed tag.
+func (e *Entry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats returns a snapshot of the call statistics of the endpoint.
+func (e *Entry) Stats() Stats {
+	return e.stats.snapshot()
+}
+
+// Option sets an optional parameter for registered endpoints.
+type Option func(*Entry)
+
+// WithBinding adds a transport binding to the endpoint.
+func WithBinding(transport string, address string) Option {
+	return func(e *Entry) { e.Bindings = append(e.Bindings, Binding{Transport: transport, Address: address}) }
+}
+
+// WithTags adds tags to the endpoint.
+func WithTags(tags ...string) Option {
+	return func(e *Entry) { e.Tags = append(e.Tags, tags...) }
+}
+
+// Register adds the endpoint to the registry under the passed name, and returns
+// an instrumented version of it which records call statistics. Only calls made
+// through the returned endpoint (or Entry.Endpoint) are counted.
+func Register[Req any, Resp any](r *Registry, name string, e endpoint.Endpoint[Req, Resp],
+	options ...Option) (endpoint.Endpoint[Req, Resp], error) {
+	entry := &Entry{
+		Name:     name,
+		Request:  reflect.TypeOf((*Req)(nil)).Elem(),
+		Response: reflect.TypeOf((*Resp)(nil)).Elem(),
+		stats:    &stats{},
+	}
+	for _, option := range options {
+		option(entry)
+	}
+
+	instrumented := func(ctx context.Context, request Req) (Resp, error) {
+		begin := entry.stats.begin()
+		response, err := e(ctx, request)
+		entry.stats.end(begin, err)
+		return response, err
+	}
+	entry.typed = endpoint.Endpoint[Req, Resp](instrumented)
+	entry.Endpoint = endpoint.ReverseAdapter[Req, Resp](instrumented)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyRegistered, name)
+	}
+	r.byName[name] = entry
+	r.entries = append(r.entries, entry)
+	return instrumented, nil
+}
+
+// MustRegister is like Register but panics if the endpoint cannot be registered.
+func MustRegister[Req any, Resp any](r *Registry, name string, e endpoint.Endpoint[Req, Resp],
+	options ...Option) endpoint.Endpoint[Req, Resp] {
+	ret, err := Register(r, name, e, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// Get returns the typed instrumented endpoint registered under the passed name.
+func Get[Req any, Resp any](r *Registry, name string) (endpoint.Endpoint[Req, Resp], error) {
+	entry, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	e, ok := entry.typed.(endpoint.Endpoint[Req, Resp])
+	if !ok {
+		return nil, fmt.Errorf("%w: endpoint %s is %v -> %v", util.ErrParameterInvalidType, name,
+			entry.Request, entry.Response)
+	}
+	return e, nil
+}
+
+// Lookup returns the entry registered under the passed name.
+func (r *Registry) Lookup(name string) (*Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.byName[name]
+	return entry, ok
+}
+
+// Entries returns all entries in registration order.
+func (r *Registry) Entries() []*Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make([]*Entry, len(r.entries))
+	copy(ret, r.entries)
+	return ret
+}
+
+// Names returns the names of all entries, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make([]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		ret = append(ret, entry.Name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Stats is a snapshot of the call statistics of an endpoint.
+type Stats struct {
+	Calls         uint64        `json:"calls"`
+	Errors        uint64        `json:"errors"`
+	InFlight      int64         `json:"in_flight"`
+	TotalDuration time.Duration `json:"total_duration"`
+	MaxDuration   time.Duration `json:"max_duration"`
+	LastCall      time.Time     `json:"last_call"`
+}
+
+// AvgDuration returns the average duration of the finished calls.
+func (s Stats) AvgDuration() time.Duration {
+	if s.InFlight < 0 || uint64(s.InFlight) >= s.Calls {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Calls-uint64(s.InFlight))
+}
+
+type stats struct {
+	calls         uint64
+	errors        uint64
+	inFlight      int64
+	totalDuration int64
+	maxDuration   int64
+	lastCall      int64
+}
+
+func (s *stats) begin() time.Time {
+	now := time.Now()
+	atomic.AddUint64(&s.calls, 1)
+	atomic.AddInt64(&s.inFlight, 1)
+	atomic.StoreInt64(&s.lastCall, now.UnixNano())
+	return now
+}
+
+func (s *stats) end(begin time.Time, err error) {
+	d := int64(time.Since(begin))
+	atomic.AddInt64(&s.inFlight, -1)
+	atomic.AddInt64(&s.totalDuration, d)
+	if err != nil {
+		atomic.AddUint64(&s.errors, 1)
+	}
+	for {
+		max := atomic.LoadInt64(&s.maxDuration)
+		if d <= max || atomic.CompareAndSwapInt64(&s.maxDuration, max, d) {
+			break
+		}
+	}
+}
+
+func (s *stats) snapshot() Stats {
+	ret := Stats{
+		Calls:         atomic.LoadUint64(&s.calls),
+		Errors:        atomic.LoadUint64(&s.errors),
+		InFlight:      atomic.LoadInt64(&s.inFlight),
+		TotalDuration: time.Duration(atomic.LoadInt64(&s.totalDuration)),
+		MaxDuration:   time.Duration(atomic.LoadInt64(&s.maxDuration)),
+	}
+	if lastCall := atomic.LoadInt64(&s.lastCall); lastCall != 0 {
+		ret.LastCall = time.Unix(0, lastCall)
+	}
+	return ret
+}
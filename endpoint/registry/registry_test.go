@@ -0,0 +1,166 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/endpoint/registry"
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+type addRequest struct {
+	A, B int
+}
+
+type addResponse struct {
+	V int
+}
+
+func addEndpoint(_ context.Context, req addRequest) (addResponse, error) {
+	if req.A < 0 {
+		return addResponse{}, errors.New("negative")
+	}
+	return addResponse{V: req.A + req.B}, nil
+}
+
+func TestRegister(t *testing.T) {
+	r := registry.New()
+
+	e, err := registry.Register(r, "add", addEndpoint,
+		registry.WithBinding("http", "POST /add"),
+		registry.WithTags("math"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := registry.Register(r, "add", addEndpoint); !errors.Is(err, registry.ErrAlreadyRegistered) {
+		t.Fatalf("expected ErrAlreadyRegistered, received %v", err)
+	}
+
+	resp, err := e(context.Background(), addRequest{A: 1, B: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if _, err := e(context.Background(), addRequest{A: -1}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	entry, ok := r.Lookup("add")
+	if !ok {
+		t.Fatal("entry not found")
+	}
+	if want, have := reflect.TypeOf(addRequest{}), entry.Request; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := reflect.TypeOf(addResponse{}), entry.Response; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := []registry.Binding{{Transport: "http", Address: "POST /add"}}, entry.Bindings; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if !entry.HasTag("math") {
+		t.Error("expected tag math")
+	}
+
+	stats := entry.Stats()
+	if want, have := uint64(2), stats.Calls; want != have {
+		t.Errorf("calls: want %d, have %d", want, have)
+	}
+	if want, have := uint64(1), stats.Errors; want != have {
+		t.Errorf("errors: want %d, have %d", want, have)
+	}
+	if want, have := int64(0), stats.InFlight; want != have {
+		t.Errorf("in flight: want %d, have %d", want, have)
+	}
+	if stats.LastCall.IsZero() {
+		t.Error("expected last call to be set")
+	}
+
+	// calls made through the non-typed endpoint are also counted
+	uresp, err := entry.Endpoint(context.Background(), addRequest{A: 2, B: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (addResponse{V: 4}), uresp; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := uint64(3), entry.Stats().Calls; want != have {
+		t.Errorf("calls: want %d, have %d", want, have)
+	}
+}
+
+func TestGet(t *testing.T) {
+	r := registry.New()
+	registry.MustRegister(r, "add", addEndpoint)
+
+	e, err := registry.Get[addRequest, addResponse](r, "add")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := e(context.Background(), addRequest{A: 5, B: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 10, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	if _, err := registry.Get[addRequest, string](r, "add"); !errors.Is(err, util.ErrParameterInvalidType) {
+		t.Errorf("expected ErrParameterInvalidType, received %v", err)
+	}
+	if _, err := registry.Get[addRequest, addResponse](r, "sub"); !errors.Is(err, registry.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, received %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := registry.New()
+	add := registry.MustRegister(r, "add", addEndpoint, registry.WithTags("math"))
+	registry.MustRegister(r, "echo", func(_ context.Context, req string) (string, error) { return req, nil })
+
+	if _, err := add(context.Background(), addRequest{A: 1, B: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"add", "echo"}},
+		{"?name=echo", []string{"echo"}},
+		{"?tag=math", []string{"add"}},
+		{"?tag=none", []string{}},
+	} {
+		rec := httptest.NewRecorder()
+		registry.NewHandler(r).ServeHTTP(rec, httptest.NewRequest("GET", "/debug/endpoints"+test.query, nil))
+
+		var list []registry.EntryInfo
+		if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, info := range list {
+			names = append(names, info.Name)
+		}
+		if !reflect.DeepEqual(test.expected, names) {
+			t.Errorf("%s: want %v, have %v", test.query, test.expected, names)
+		}
+		for _, info := range list {
+			if info.Name == "add" {
+				if want, have := "registry_test.addRequest", info.Request; want != have {
+					t.Errorf("want %s, have %s", want, have)
+				}
+				if want, have := uint64(1), info.Stats.Calls; want != have {
+					t.Errorf("want %d, have %d", want, have)
+				}
+			}
+		}
+	}
+}
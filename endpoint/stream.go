@@ -0,0 +1,261 @@
+package endpoint
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// ServerStreamEndpoint represents a single RPC method which produces a
+// sequence of responses for a single request, calling send for each one. If
+// send returns an error, like when the client disconnects, the endpoint must
+// stop and return it.
+type ServerStreamEndpoint[Req any, Resp any] func(ctx context.Context, request Req, send func(Resp) error) error
+
+// ClientStreamEndpoint represents a single RPC method which consumes a
+// sequence of requests and produces a single response. recv returns io.EOF
+// after the last request.
+type ClientStreamEndpoint[Req any, Resp any] func(ctx context.Context, recv func() (Req, error)) (response Resp, err error)
+
+// BidiStreamEndpoint represents a single RPC method which consumes a sequence
+// of requests and produces a sequence of responses. recv returns io.EOF after
+// the last request.
+type BidiStreamEndpoint[Req any, Resp any] func(ctx context.Context, recv func() (Req, error), send func(Resp) error) error
+
+// ServerStreamMiddleware is a chainable behavior modifier for server-streaming
+// endpoints.
+type ServerStreamMiddleware[Req any, Resp any] func(ServerStreamEndpoint[Req, Resp]) ServerStreamEndpoint[Req, Resp]
+
+// ClientStreamMiddleware is a chainable behavior modifier for client-streaming
+// endpoints.
+type ClientStreamMiddleware[Req any, Resp any] func(ClientStreamEndpoint[Req, Resp]) ClientStreamEndpoint[Req, Resp]
+
+// BidiStreamMiddleware is a chainable behavior modifier for bidirectional
+// streaming endpoints.
+type BidiStreamMiddleware[Req any, Resp any] func(BidiStreamEndpoint[Req, Resp]) BidiStreamEndpoint[Req, Resp]
+
+// ServerStreamFromUnary adapts a unary endpoint to a server-streaming endpoint
+// which sends its only response.
+func ServerStreamFromUnary[Req any, Resp any](e Endpoint[Req, Resp]) ServerStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, request Req, send func(Resp) error) error {
+		response, err := e(ctx, request)
+		if err != nil {
+			return err
+		}
+		return send(response)
+	}
+}
+
+// ServerStreamToUnary adapts a server-streaming endpoint to a unary endpoint
+// which returns all the responses. If the stream fails, the responses
+// received until then are returned with the error.
+func ServerStreamToUnary[Req any, Resp any](e ServerStreamEndpoint[Req, Resp]) Endpoint[Req, []Resp] {
+	return func(ctx context.Context, request Req) ([]Resp, error) {
+		var responses []Resp
+		err := e(ctx, request, func(response Resp) error {
+			responses = append(responses, response)
+			return nil
+		})
+		return responses, err
+	}
+}
+
+// ClientStreamFromUnary adapts a unary endpoint which takes all the requests
+// at once to a client-streaming endpoint.
+func ClientStreamFromUnary[Req any, Resp any](e Endpoint[[]Req, Resp]) ClientStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, recv func() (Req, error)) (Resp, error) {
+		var requests []Req
+		for {
+			request, err := recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				var r Resp
+				return r, err
+			}
+			requests = append(requests, request)
+		}
+		return e(ctx, requests)
+	}
+}
+
+// ClientStreamToUnary adapts a client-streaming endpoint to a unary endpoint
+// which takes all the requests at once.
+func ClientStreamToUnary[Req any, Resp any](e ClientStreamEndpoint[Req, Resp]) Endpoint[[]Req, Resp] {
+	return func(ctx context.Context, requests []Req) (Resp, error) {
+		return e(ctx, RecvSlice(requests))
+	}
+}
+
+// BidiStreamFromUnary adapts a unary endpoint to a bidirectional streaming
+// endpoint which calls it for each request and sends its response. The first
+// error ends the stream.
+func BidiStreamFromUnary[Req any, Resp any](e Endpoint[Req, Resp]) BidiStreamEndpoint[Req, Resp] {
+	return BidiStreamFromServerStream(ServerStreamFromUnary(e))
+}
+
+// BidiStreamFromServerStream adapts a server-streaming endpoint to a
+// bidirectional streaming endpoint which calls it for each request, in order.
+// The first error ends the stream.
+func BidiStreamFromServerStream[Req any, Resp any](e ServerStreamEndpoint[Req, Resp]) BidiStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, recv func() (Req, error), send func(Resp) error) error {
+		for {
+			request, err := recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := e(ctx, request, send); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RecvSlice returns a recv function which returns the requests in order, and
+// io.EOF after the last one.
+func RecvSlice[Req any](requests []Req) func() (Req, error) {
+	i := 0
+	return func() (Req, error) {
+		if i >= len(requests) {
+			var r Req
+			return r, io.EOF
+		}
+		i++
+		return requests[i-1], nil
+	}
+}
+
+// SendContext wraps send so that it returns the context error once the
+// context is done, without calling send. A call already blocked in send is not
+// interrupted, so send must return by itself when the context is done, as the
+// stream transports do. After send or the context fail, the same error is
+// returned for every call.
+func SendContext[Resp any](ctx context.Context, send func(Resp) error) func(Resp) error {
+	var t terminator
+	return func(response Resp) error {
+		return t.do(ctx, func() error { return send(response) })
+	}
+}
+
+// RecvContext wraps recv so that it returns the context error once the
+// context is done, without calling recv. A call already blocked in recv is not
+// interrupted, so recv must return by itself when the context is done, as the
+// stream transports do. After recv or the context fail, including with io.EOF,
+// the same error is returned for every call.
+func RecvContext[Req any](ctx context.Context, recv func() (Req, error)) func() (Req, error) {
+	return recvTerminator(ctx, &terminator{}, recv)
+}
+
+// ServerStreamContext returns a middleware which wraps send with SendContext,
+// and returns the send error when the endpoint ignores it and returns nil.
+func ServerStreamContext[Req any, Resp any]() ServerStreamMiddleware[Req, Resp] {
+	return func(next ServerStreamEndpoint[Req, Resp]) ServerStreamEndpoint[Req, Resp] {
+		return func(ctx context.Context, request Req, send func(Resp) error) error {
+			var t terminator
+			err := next(ctx, request, func(response Resp) error {
+				return t.do(ctx, func() error { return send(response) })
+			})
+			if err == nil {
+				err = t.error()
+			}
+			return err
+		}
+	}
+}
+
+// ClientStreamContext returns a middleware which wraps recv with RecvContext,
+// and returns the recv error when the endpoint ignores it and returns nil.
+// io.EOF is not considered an error.
+func ClientStreamContext[Req any, Resp any]() ClientStreamMiddleware[Req, Resp] {
+	return func(next ClientStreamEndpoint[Req, Resp]) ClientStreamEndpoint[Req, Resp] {
+		return func(ctx context.Context, recv func() (Req, error)) (Resp, error) {
+			var t terminator
+			response, err := next(ctx, recvTerminator(ctx, &t, recv))
+			if err == nil {
+				if err = t.error(); err != nil {
+					var r Resp
+					return r, err
+				}
+			}
+			return response, err
+		}
+	}
+}
+
+// BidiStreamContext returns a middleware which wraps recv with RecvContext and
+// send with SendContext, and returns their error when the endpoint ignores it
+// and returns nil. io.EOF is not considered an error.
+func BidiStreamContext[Req any, Resp any]() BidiStreamMiddleware[Req, Resp] {
+	return func(next BidiStreamEndpoint[Req, Resp]) BidiStreamEndpoint[Req, Resp] {
+		return func(ctx context.Context, recv func() (Req, error), send func(Resp) error) error {
+			var rt, st terminator
+			err := next(ctx, recvTerminator(ctx, &rt, recv), func(response Resp) error {
+				return st.do(ctx, func() error { return send(response) })
+			})
+			if err == nil {
+				err = st.error()
+			}
+			if err == nil {
+				err = rt.error()
+			}
+			return err
+		}
+	}
+}
+
+func recvTerminator[Req any](ctx context.Context, t *terminator, recv func() (Req, error)) func() (Req, error) {
+	return func() (Req, error) {
+		var request Req
+		err := t.do(ctx, func() error {
+			var err error
+			request, err = recv()
+			return err
+		})
+		return request, err
+	}
+}
+
+// terminator keeps the first error of a stream direction.
+type terminator struct {
+	mu  sync.Mutex
+	err error
+}
+
+// do calls f, unless the stream direction already ended or the context is
+// done. The lock is not held while f runs, so error doesn't wait for a blocked
+// call.
+func (t *terminator) do(ctx context.Context, f func() error) error {
+	t.mu.Lock()
+	if t.err == nil {
+		t.err = ctx.Err()
+	}
+	err := t.err
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err = f(); err == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		t.err = err
+	}
+	return t.err
+}
+
+// error returns the error which ended the stream direction, except io.EOF.
+func (t *terminator) error() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == io.EOF {
+		return nil
+	}
+	return t.err
+}
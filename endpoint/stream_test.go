@@ -0,0 +1,219 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func countStream(ctx context.Context, request int, send func(string) error) error {
+	for i := 1; i <= request; i++ {
+		if err := send(fmt.Sprintf("v-%d", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sumStream(ctx context.Context, recv func() (int, error)) (int, error) {
+	sum := 0
+	for {
+		request, err := recv()
+		if err == io.EOF {
+			return sum, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		sum += request
+	}
+}
+
+func collect[Resp any](responses *[]Resp) func(Resp) error {
+	return func(response Resp) error {
+		*responses = append(*responses, response)
+		return nil
+	}
+}
+
+func TestServerStreamAdapters(t *testing.T) {
+	format := func(ctx context.Context, request int) (string, error) {
+		if request < 0 {
+			return "", errors.New("negative")
+		}
+		return fmt.Sprintf("v-%d", request), nil
+	}
+
+	var responses []string
+	if err := ServerStreamFromUnary(format)(context.Background(), 5, collect(&responses)); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"v-5"}, responses; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if err := ServerStreamFromUnary(format)(context.Background(), -1, collect(&responses)); err == nil {
+		t.Error("want error, have nil")
+	}
+
+	all, err := ServerStreamToUnary[int, string](countStream)(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"v-1", "v-2", "v-3"}, all; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestClientStreamAdapters(t *testing.T) {
+	sum, err := ClientStreamToUnary[int, int](sumStream)(context.Background(), []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 6, sum; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	count := ClientStreamFromUnary(func(ctx context.Context, requests []int) (int, error) {
+		return len(requests), nil
+	})
+	n, err := count(context.Background(), RecvSlice([]int{4, 5}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, n; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	errRecv := errors.New("recv")
+	_, err = count(context.Background(), func() (int, error) { return 0, errRecv })
+	if want, have := errRecv, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestBidiStreamAdapters(t *testing.T) {
+	var responses []string
+	err := BidiStreamFromServerStream[int, string](countStream)(context.Background(), RecvSlice([]int{1, 2}),
+		collect(&responses))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"v-1", "v-1", "v-2"}, responses; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	responses = nil
+	double := func(ctx context.Context, request int) (string, error) {
+		return fmt.Sprint(request * 2), nil
+	}
+	if err := BidiStreamFromUnary(double)(context.Background(), RecvSlice([]int{1, 2}), collect(&responses)); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"2", "4"}, responses; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestSendRecvContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var responses []int
+	send := SendContext(ctx, collect(&responses))
+	recv := RecvContext(ctx, RecvSlice([]int{1, 2}))
+
+	if err := send(1); err != nil {
+		t.Fatal(err)
+	}
+	if request, err := recv(); err != nil || request != 1 {
+		t.Fatalf("want 1, have %v (%v)", request, err)
+	}
+
+	cancel()
+	if want, have := context.Canceled, send(2); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if _, err := recv(); err != context.Canceled {
+		t.Errorf("want %v, have %v", context.Canceled, err)
+	}
+	if want, have := []int{1}, responses; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestStreamContextMiddlewares(t *testing.T) {
+	errSend := errors.New("send")
+
+	// the endpoint ignores the send error, the middleware returns it.
+	ignoring := ServerStreamContext[int, string]()(func(ctx context.Context, request int, send func(string) error) error {
+		for i := 0; i < request; i++ {
+			_ = send("x")
+		}
+		return nil
+	})
+	calls := 0
+	err := ignoring(context.Background(), 3, func(string) error {
+		calls++
+		return errSend
+	})
+	if want, have := errSend, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := 1, calls; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	// io.EOF is not an error.
+	sum, err := ClientStreamContext[int, int]()(sumStream)(context.Background(), RecvSlice([]int{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, sum; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bidi := BidiStreamContext[int, string]()(func(ctx context.Context, recv func() (int, error), send func(string) error) error {
+		_, _ = recv()
+		return nil
+	})
+	if want, have := context.Canceled, bidi(ctx, RecvSlice([]int{1}), collect(new([]string))); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestStreamContextBlockedRecv(t *testing.T) {
+	// the endpoint returns while a recv call is still blocked, which must not
+	// block the middleware.
+	release := make(chan struct{})
+	recvDone := make(chan struct{})
+	bidi := BidiStreamContext[int, string]()(func(ctx context.Context, recv func() (int, error), send func(string) error) error {
+		go func() {
+			defer close(recvDone)
+			_, _ = recv()
+		}()
+		return nil
+	})
+	done := make(chan error, 1)
+	go func() {
+		done <- bidi(context.Background(), func() (int, error) {
+			<-release
+			return 0, io.EOF
+		}, collect(new([]string)))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("want nil error, have %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("middleware blocked on the pending recv")
+	}
+	close(release)
+	<-recvDone
+}
@@ -0,0 +1,227 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+var (
+	ErrInvalidStruct = errors.New("invalid endpoints struct")
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// StructField describes an endpoint field of an endpoints struct, like:
+//
+//	type Endpoints struct {
+//	    PostProfileEndpoint endpoint.Endpoint[postProfileRequest, postProfileResponse]
+//	    GetProfileEndpoint  endpoint.Endpoint[getProfileRequest, getProfileResponse]
+//	}
+type StructField struct {
+	// Name is the name of the struct field.
+	Name string
+	// Tag is the tag of the struct field.
+	Tag reflect.StructTag
+	// Type is the type of the struct field.
+	Type reflect.Type
+	// Request is the type of the endpoint request.
+	Request reflect.Type
+	// Response is the type of the endpoint response.
+	Response reflect.Type
+	// Endpoint is the non-typed version of the field value, or nil if the field is nil.
+	Endpoint gokitendpoint.Endpoint
+}
+
+// StructFields returns all exported endpoint fields of the passed struct or
+// pointer to struct. Any field with a function type in the format
+// func(context.Context, Req) (Resp, error) is considered an endpoint.
+func StructFields(endpoints interface{}) ([]StructField, error) {
+	v, err := structValue(endpoints, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []StructField
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !isStructEndpointField(sf) {
+			continue
+		}
+		field := StructField{
+			Name:     sf.Name,
+			Tag:      sf.Tag,
+			Type:     sf.Type,
+			Request:  sf.Type.In(1),
+			Response: sf.Type.Out(0),
+		}
+		if fv := v.Field(i); !fv.IsNil() {
+			field.Endpoint = reflectReverseAdapter(fv)
+		}
+		ret = append(ret, field)
+	}
+	return ret, nil
+}
+
+// WrapStruct replaces each non-nil endpoint field of the struct pointed to by
+// endpoints with the endpoint returned by wrap, which receives the field name
+// and the non-typed version of the field value. If wrap returns nil the field
+// is not changed.
+func WrapStruct(endpoints interface{},
+	wrap func(name string, e gokitendpoint.Endpoint) gokitendpoint.Endpoint) error {
+	v, err := structValue(endpoints, true)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		fv := v.Field(i)
+		if !isStructEndpointField(sf) || fv.IsNil() {
+			continue
+		}
+		if e := wrap(sf.Name, reflectReverseAdapter(fv)); e != nil {
+			fv.Set(reflectAdapter(sf.Type, e))
+		}
+	}
+	return nil
+}
+
+// ReverseAdapterStruct sets each gokitendpoint.Endpoint field of the struct
+// pointed to by untyped to the non-typed version of the typed struct field with
+// the same name. Fields without a matching typed field are not changed.
+func ReverseAdapterStruct(typed interface{}, untyped interface{}) error {
+	fields, err := StructFields(typed)
+	if err != nil {
+		return err
+	}
+	v, err := structValue(untyped, true)
+	if err != nil {
+		return err
+	}
+
+	gokitEndpointType := reflect.TypeOf(gokitendpoint.Endpoint(nil))
+	for _, field := range fields {
+		fv := v.FieldByName(field.Name)
+		if !fv.IsValid() || !fv.CanSet() || fv.Type() != gokitEndpointType {
+			continue
+		}
+		fv.Set(reflect.ValueOf(field.Endpoint))
+	}
+	return nil
+}
+
+// AdapterStruct sets each typed endpoint field of the struct pointed to by
+// typed to the typed version of the gokitendpoint.Endpoint field of the untyped
+// struct with the same name. Fields without a matching non-typed field are not
+// changed.
+func AdapterStruct(untyped interface{}, typed interface{}) error {
+	u, err := structValue(untyped, false)
+	if err != nil {
+		return err
+	}
+	v, err := structValue(typed, true)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !isStructEndpointField(sf) {
+			continue
+		}
+		uv := u.FieldByName(sf.Name)
+		if !uv.IsValid() || !uv.CanInterface() {
+			continue
+		}
+		e, ok := uv.Interface().(gokitendpoint.Endpoint)
+		if !ok {
+			continue
+		}
+		if e == nil {
+			v.Field(i).Set(reflect.Zero(sf.Type))
+		} else {
+			v.Field(i).Set(reflectAdapter(sf.Type, e))
+		}
+	}
+	return nil
+}
+
+func structValue(s interface{}, settable bool) (reflect.Value, error) {
+	v := reflect.ValueOf(s)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%w: nil pointer", ErrInvalidStruct)
+		}
+		v = v.Elem()
+	} else if settable {
+		return reflect.Value{}, fmt.Errorf("%w: %T is not a pointer to struct", ErrInvalidStruct, s)
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %T is not a struct", ErrInvalidStruct, s)
+	}
+	return v, nil
+}
+
+func isStructEndpointField(sf reflect.StructField) bool {
+	return sf.PkgPath == "" && isEndpointType(sf.Type)
+}
+
+func isEndpointType(t reflect.Type) bool {
+	return t.Kind() == reflect.Func && !t.IsVariadic() &&
+		t.NumIn() == 2 && t.In(0) == contextType &&
+		t.NumOut() == 2 && t.Out(1) == errorType
+}
+
+// reflectReverseAdapter is an adapter from a typed endpoint value to a standard go-kit version.
+func reflectReverseAdapter(e reflect.Value) gokitendpoint.Endpoint {
+	// copy the value, as it may be a struct field which is going to be replaced
+	e = reflect.ValueOf(e.Interface())
+	reqType := e.Type().In(1)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := reflect.Zero(reqType)
+		if request != nil {
+			req = reflect.ValueOf(request)
+			if !req.Type().AssignableTo(reqType) {
+				return nil, util.ErrParameterInvalidType
+			}
+		}
+		rctx := reflect.Zero(contextType)
+		if ctx != nil {
+			rctx = reflect.ValueOf(ctx)
+		}
+		out := e.Call([]reflect.Value{rctx, req})
+		err, _ := out[1].Interface().(error)
+		return out[0].Interface(), err
+	}
+}
+
+// reflectAdapter is an adapter from a standard go-kit endpoint to a typed endpoint value of type t.
+func reflectAdapter(t reflect.Type, e gokitendpoint.Endpoint) reflect.Value {
+	respType := t.Out(0)
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		ctx, _ := args[0].Interface().(context.Context)
+		response, err := e(ctx, args[1].Interface())
+
+		resp := reflect.New(respType).Elem()
+		if err == nil && response != nil {
+			if rv := reflect.ValueOf(response); rv.Type().AssignableTo(respType) {
+				resp.Set(rv)
+			} else {
+				err = util.ErrParameterInvalidType
+			}
+		}
+		rerr := reflect.New(errorType).Elem()
+		if err != nil {
+			rerr.Set(reflect.ValueOf(err))
+		}
+		return []reflect.Value{resp, rerr}
+	})
+}
@@ -0,0 +1,165 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+type testEndpoints struct {
+	Add    Endpoint[int, int]
+	Format Endpoint[int, string]
+	Nil    Endpoint[string, string]
+	Value  string
+	hidden Endpoint[int, int]
+}
+
+type testStdEndpoints struct {
+	Add    gokitendpoint.Endpoint
+	Format gokitendpoint.Endpoint
+	Other  gokitendpoint.Endpoint
+}
+
+func newTestEndpoints() testEndpoints {
+	return testEndpoints{
+		Add: func(ctx context.Context, request int) (int, error) {
+			return request + 2, nil
+		},
+		Format: func(ctx context.Context, request int) (string, error) {
+			if request < 0 {
+				return "", errors.New("negative")
+			}
+			return fmt.Sprintf("v-%d", request), nil
+		},
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	fields, err := StructFields(newTestEndpoints())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	if want, have := []string{"Add", "Format", "Nil"}, names; !reflect.DeepEqual(want, have) {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+	if want, have := reflect.TypeOf(0), fields[1].Request; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := reflect.TypeOf(""), fields[1].Response; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if fields[2].Endpoint != nil {
+		t.Error("expected nil endpoint")
+	}
+
+	resp, err := fields[1].Endpoint(context.Background(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "v-5", resp; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if _, err := fields[1].Endpoint(context.Background(), "bad"); !errors.Is(err, util.ErrParameterInvalidType) {
+		t.Errorf("expected ErrParameterInvalidType, received %v", err)
+	}
+
+	if _, err := StructFields(12); !errors.Is(err, ErrInvalidStruct) {
+		t.Errorf("expected ErrInvalidStruct, received %v", err)
+	}
+}
+
+func TestWrapStruct(t *testing.T) {
+	e := newTestEndpoints()
+
+	var methods []string
+	err := WrapStruct(&e, func(name string, next gokitendpoint.Endpoint) gokitendpoint.Endpoint {
+		methods = append(methods, name)
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			return next(ctx, request.(int)*10)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"Add", "Format"}, methods; !reflect.DeepEqual(want, have) {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	add, err := e.Add(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 12, add; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	format, err := e.Format(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "v-10", format; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if _, err := e.Format(context.Background(), -1); err == nil {
+		t.Error("expected error")
+	}
+
+	if err := WrapStruct(e, nil); !errors.Is(err, ErrInvalidStruct) {
+		t.Errorf("expected ErrInvalidStruct, received %v", err)
+	}
+}
+
+func TestWrapStructBadResponse(t *testing.T) {
+	e := newTestEndpoints()
+
+	err := WrapStruct(&e, func(name string, next gokitendpoint.Endpoint) gokitendpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			return "bad_type", nil
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Add(context.Background(), 1); !errors.Is(err, util.ErrParameterInvalidType) {
+		t.Errorf("expected ErrParameterInvalidType, received %v", err)
+	}
+}
+
+func TestReverseAdapterStruct(t *testing.T) {
+	var std testStdEndpoints
+	if err := ReverseAdapterStruct(newTestEndpoints(), &std); err != nil {
+		t.Fatal(err)
+	}
+	if std.Other != nil {
+		t.Error("expected unmatched field to be nil")
+	}
+
+	resp, err := std.Add(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 5, resp; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	var typed testEndpoints
+	if err := AdapterStruct(std, &typed); err != nil {
+		t.Fatal(err)
+	}
+	format, err := typed.Format(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "v-3", format; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
@@ -0,0 +1,96 @@
+package errkind
+
+import (
+	"errors"
+	"time"
+)
+
+// Detail is structured information about an error, which transports that
+// support it send along with the error. It is implemented by BadRequest,
+// RetryInfo and ErrorInfo.
+type Detail interface {
+	isDetail()
+}
+
+// FieldViolation describes a single invalid field of a request.
+type FieldViolation struct {
+	// Field is the path of the field, like "user.emails[1]".
+	Field string
+	// Description explains why the field is invalid.
+	Description string
+}
+
+// BadRequest is a Detail with the invalid fields of a request.
+type BadRequest struct {
+	FieldViolations []FieldViolation
+}
+
+// RetryInfo is a Detail with how long clients should wait before retrying the
+// request.
+type RetryInfo struct {
+	RetryDelay time.Duration
+}
+
+// ErrorInfo is a Detail with the cause of the error in a machine readable
+// form.
+type ErrorInfo struct {
+	// Reason is a constant identifying the cause of the error, like
+	// "QUOTA_EXCEEDED".
+	Reason string
+	// Domain is the logical grouping to which Reason belongs, usually the
+	// service name.
+	Domain string
+	// Metadata is additional information about the error.
+	Metadata map[string]string
+}
+
+func (BadRequest) isDetail() {}
+func (RetryInfo) isDetail()  {}
+func (ErrorInfo) isDetail()  {}
+
+// Detailer is implemented by errors which have details.
+type Detailer interface {
+	ErrorDetails() []Detail
+}
+
+// ErrorDetails implements Detailer.
+func (e *Error) ErrorDetails() []Detail {
+	return e.Details
+}
+
+// WithDetails returns an error which wraps err with the details, and has the
+// same kind as err.
+func WithDetails(err error, details ...Detail) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: KindOf(err), Err: err, Details: details}
+}
+
+// DetailsOf returns the details of every error in the chain of err which
+// implements Detailer, outermost first.
+func DetailsOf(err error) []Detail {
+	var ret []Detail
+	for err != nil {
+		if detailer, ok := err.(Detailer); ok {
+			ret = append(ret, detailer.ErrorDetails()...)
+		}
+		err = errors.Unwrap(err)
+	}
+	return ret
+}
+
+// DetailOf returns the first detail of type D of err.
+//
+//	if br, ok := errkind.DetailOf[errkind.BadRequest](err); ok {
+//		for _, v := range br.FieldViolations { ... }
+//	}
+func DetailOf[D Detail](err error) (D, bool) {
+	for _, detail := range DetailsOf(err) {
+		if d, ok := detail.(D); ok {
+			return d, true
+		}
+	}
+	var d D
+	return d, false
+}
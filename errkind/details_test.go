@@ -0,0 +1,47 @@
+package errkind_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+)
+
+func TestDetails(t *testing.T) {
+	badRequest := errkind.BadRequest{FieldViolations: []errkind.FieldViolation{
+		{Field: "name", Description: "required"},
+	}}
+	retry := errkind.RetryInfo{RetryDelay: time.Second}
+	err := fmt.Errorf("op: %w", errkind.WithDetails(
+		errkind.WithDetails(errkind.New(errkind.InvalidArgument, "invalid user"), badRequest), retry))
+
+	if want, have := errkind.InvalidArgument, errkind.KindOf(err); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "op: invalid user", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := []errkind.Detail{retry, badRequest}, errkind.DetailsOf(err); !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	br, ok := errkind.DetailOf[errkind.BadRequest](err)
+	if !ok {
+		t.Fatal("want BadRequest detail")
+	}
+	if want, have := badRequest, br; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if _, ok := errkind.DetailOf[errkind.ErrorInfo](err); ok {
+		t.Error("want no ErrorInfo detail")
+	}
+	if details := errkind.DetailsOf(errors.New("dang")); details != nil {
+		t.Errorf("want no details, have %v", details)
+	}
+	if err := errkind.WithDetails(nil, retry); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package errkind defines transport independent kinds of errors, which every
+// transport maps to its own status codes.
+//
+// Servers encode errors with a kind automatically, and clients reconstruct the
+// same kind from the response, so errors.Is works across the wire:
+//
+//	return errkind.New(errkind.NotFound, "user not found")
+//
+//	if errors.Is(err, errkind.NotFound) { ... }
+//
+// HTTP clients and NATS publishers reconstruct the kind only when configured
+// to, with an error response decoder like DecodeTextErrorResponse for HTTP and
+// DecodeErrorKind for NATS.
+package errkind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kind is the kind of an error. It implements error, so a Kind can be returned
+// as is, and used as the target of errors.Is.
+type Kind string
+
+// The error kinds.
+const (
+	Unknown            Kind = "unknown"
+	Canceled           Kind = "canceled"
+	InvalidArgument    Kind = "invalid_argument"
+	NotFound           Kind = "not_found"
+	AlreadyExists      Kind = "already_exists"
+	Conflict           Kind = "conflict"
+	PermissionDenied   Kind = "permission_denied"
+	Unauthenticated    Kind = "unauthenticated"
+	ResourceExhausted  Kind = "resource_exhausted"
+	FailedPrecondition Kind = "failed_precondition"
+	Unimplemented      Kind = "unimplemented"
+	Unavailable        Kind = "unavailable"
+	DeadlineExceeded   Kind = "deadline_exceeded"
+	Internal           Kind = "internal"
+)
+
+// Header is the header used by the HTTP and NATS transports to send the kind
+// of an error.
+const Header = "Error-Kind"
+
+// Error implements error.
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// ErrorKind implements Kinder.
+func (k Kind) ErrorKind() Kind {
+	return k
+}
+
+// Kinder is implemented by errors which have a kind.
+type Kinder interface {
+	ErrorKind() Kind
+}
+
+// Error is an error with a kind.
+type Error struct {
+	Kind Kind
+	Err  error
+	// Details are sent by the transports which support them.
+	Details []Detail
+}
+
+// New returns an error of the kind with the message.
+func New(kind Kind, message string) error {
+	return &Error{Kind: kind, Err: errors.New(message)}
+}
+
+// Errorf returns an error of the kind with the message formatted by
+// fmt.Errorf, so %w can be used to wrap other errors.
+func Errorf(kind Kind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap returns an error of the kind which wraps err. It returns nil if err is
+// nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Kind)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of the error.
+func (e *Error) Is(target error) bool {
+	k, ok := target.(Kind)
+	return ok && k == e.Kind
+}
+
+// ErrorKind implements Kinder.
+func (e *Error) ErrorKind() Kind {
+	return e.Kind
+}
+
+// KindOf returns the kind of the first error in the chain of err which
+// implements Kinder. Context cancellation and deadline errors have the
+// Canceled and DeadlineExceeded kinds, and other errors the Unknown kind. It
+// returns the empty kind if err is nil.
+func KindOf(err error) Kind {
+	if err == nil {
+		return ""
+	}
+	var kinder Kinder
+	if errors.As(err, &kinder) {
+		return kinder.ErrorKind()
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	}
+	return Unknown
+}
@@ -0,0 +1,61 @@
+package errkind_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+)
+
+func TestKindOf(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want errkind.Kind
+	}{
+		{"nil", nil, ""},
+		{"plain", errors.New("dang"), errkind.Unknown},
+		{"kind", errkind.NotFound, errkind.NotFound},
+		{"error", errkind.New(errkind.Conflict, "dang"), errkind.Conflict},
+		{"wrapped", fmt.Errorf("op: %w", errkind.New(errkind.Unavailable, "dang")), errkind.Unavailable},
+		{"outermost", errkind.Wrap(errkind.Internal, errkind.New(errkind.NotFound, "dang")), errkind.Internal},
+		{"canceled", fmt.Errorf("op: %w", context.Canceled), errkind.Canceled},
+		{"deadline", context.DeadlineExceeded, errkind.DeadlineExceeded},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if want, have := tc.want, errkind.KindOf(tc.err); want != have {
+				t.Errorf("want %q, have %q", want, have)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	cause := errors.New("dang")
+	err := fmt.Errorf("op: %w", errkind.Wrap(errkind.NotFound, cause))
+
+	if !errors.Is(err, errkind.NotFound) {
+		t.Errorf("want errors.Is %q", errkind.NotFound)
+	}
+	if errors.Is(err, errkind.Conflict) {
+		t.Errorf("want not errors.Is %q", errkind.Conflict)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("want errors.Is cause")
+	}
+	if want, have := "op: dang", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "user 12: missing", errkind.Errorf(errkind.NotFound, "user %d: %w", 12,
+		errors.New("missing")).Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "not_found", (&errkind.Error{Kind: errkind.NotFound}).Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if err := errkind.Wrap(errkind.NotFound, nil); err != nil {
+		t.Errorf("want nil, have %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package errkind
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// mapping is the default mapping of a kind to the transport codes.
+type mapping struct {
+	kind       Kind
+	httpStatus int
+	grpcCode   codes.Code
+	jsonrpc    int
+}
+
+// mappings are looked up in order, so when more than one kind maps to the
+// same code, the first one is the kind returned for the code.
+var mappings = []mapping{
+	{Internal, http.StatusInternalServerError, codes.Internal, -32603},
+	{Unknown, http.StatusInternalServerError, codes.Unknown, -32000},
+	{Canceled, 499, codes.Canceled, -32001},
+	{InvalidArgument, http.StatusBadRequest, codes.InvalidArgument, -32602},
+	{NotFound, http.StatusNotFound, codes.NotFound, -32002},
+	{Conflict, http.StatusConflict, codes.Aborted, -32004},
+	{AlreadyExists, http.StatusConflict, codes.AlreadyExists, -32003},
+	{PermissionDenied, http.StatusForbidden, codes.PermissionDenied, -32005},
+	{Unauthenticated, http.StatusUnauthorized, codes.Unauthenticated, -32006},
+	{ResourceExhausted, http.StatusTooManyRequests, codes.ResourceExhausted, -32007},
+	{FailedPrecondition, http.StatusPreconditionFailed, codes.FailedPrecondition, -32008},
+	{Unimplemented, http.StatusNotImplemented, codes.Unimplemented, -32601},
+	{Unavailable, http.StatusServiceUnavailable, codes.Unavailable, -32009},
+	{DeadlineExceeded, http.StatusGatewayTimeout, codes.DeadlineExceeded, -32010},
+}
+
+func lookup(match func(m mapping) bool) (mapping, bool) {
+	for _, m := range mappings {
+		if match(m) {
+			return m, true
+		}
+	}
+	return mapping{}, false
+}
+
+func lookupKind(kind Kind) mapping {
+	m, ok := lookup(func(m mapping) bool { return m.kind == kind })
+	if !ok {
+		m, _ = lookup(func(m mapping) bool { return m.kind == Unknown })
+	}
+	return m
+}
+
+// HTTPStatus returns the HTTP status of the kind. Kinds not defined by this
+// package have the 500 status.
+func HTTPStatus(kind Kind) int {
+	return lookupKind(kind).httpStatus
+}
+
+// FromHTTPStatus returns the kind of the HTTP status. When more than one kind
+// has the status, the most generic one is returned. Other 4xx statuses are
+// InvalidArgument, other 5xx statuses Internal, and any other status Unknown.
+func FromHTTPStatus(status int) Kind {
+	switch status {
+	case http.StatusMethodNotAllowed:
+		return Unimplemented
+	case http.StatusRequestTimeout:
+		return DeadlineExceeded
+	case http.StatusBadGateway:
+		return Unavailable
+	}
+	if m, ok := lookup(func(m mapping) bool { return m.httpStatus == status }); ok {
+		return m.kind
+	}
+	switch {
+	case status >= 400 && status <= 499:
+		return InvalidArgument
+	case status >= 500 && status <= 599:
+		return Internal
+	}
+	return Unknown
+}
+
+// GRPCCode returns the gRPC code of the kind. Kinds not defined by this
+// package have the Unknown code.
+func GRPCCode(kind Kind) codes.Code {
+	return lookupKind(kind).grpcCode
+}
+
+// FromGRPCCode returns the kind of the gRPC code. OutOfRange is
+// InvalidArgument, DataLoss is Internal, and OK returns the empty kind.
+func FromGRPCCode(code codes.Code) Kind {
+	switch code {
+	case codes.OK:
+		return ""
+	case codes.OutOfRange:
+		return InvalidArgument
+	case codes.DataLoss:
+		return Internal
+	}
+	if m, ok := lookup(func(m mapping) bool { return m.grpcCode == code }); ok {
+		return m.kind
+	}
+	return Unknown
+}
+
+// JSONRPCCode returns the JSON-RPC error code of the kind. InvalidArgument,
+// Unimplemented and Internal use the codes of the specification, and the
+// other kinds codes of the -32000 to -32099 range reserved for server errors.
+// Kinds not defined by this package have the -32000 code.
+func JSONRPCCode(kind Kind) int {
+	return lookupKind(kind).jsonrpc
+}
+
+// FromJSONRPCCode returns the kind of the JSON-RPC error code. The parse and
+// invalid request errors of the specification are InvalidArgument, and codes
+// without a kind Unknown.
+func FromJSONRPCCode(code int) Kind {
+	switch code {
+	case -32700, -32600:
+		return InvalidArgument
+	}
+	if m, ok := lookup(func(m mapping) bool { return m.jsonrpc == code }); ok {
+		return m.kind
+	}
+	return Unknown
+}
@@ -0,0 +1,85 @@
+package errkind_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"google.golang.org/grpc/codes"
+)
+
+var allKinds = []errkind.Kind{
+	errkind.Unknown, errkind.Canceled, errkind.InvalidArgument, errkind.NotFound, errkind.AlreadyExists,
+	errkind.Conflict, errkind.PermissionDenied, errkind.Unauthenticated, errkind.ResourceExhausted,
+	errkind.FailedPrecondition, errkind.Unimplemented, errkind.Unavailable, errkind.DeadlineExceeded,
+	errkind.Internal,
+}
+
+func TestGRPCRoundTrip(t *testing.T) {
+	for _, kind := range allKinds {
+		if want, have := kind, errkind.FromGRPCCode(errkind.GRPCCode(kind)); want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}
+	if want, have := codes.Unknown, errkind.GRPCCode("custom"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := errkind.InvalidArgument, errkind.FromGRPCCode(codes.OutOfRange); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestJSONRPCRoundTrip(t *testing.T) {
+	seen := map[int]errkind.Kind{}
+	for _, kind := range allKinds {
+		code := errkind.JSONRPCCode(kind)
+		if other, ok := seen[code]; ok {
+			t.Errorf("code %d used by %q and %q", code, other, kind)
+		}
+		seen[code] = kind
+		if want, have := kind, errkind.FromJSONRPCCode(code); want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}
+	if want, have := -32602, errkind.JSONRPCCode(errkind.InvalidArgument); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := errkind.InvalidArgument, errkind.FromJSONRPCCode(-32700); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	for _, tc := range []struct {
+		kind   errkind.Kind
+		status int
+		back   errkind.Kind
+	}{
+		{errkind.NotFound, http.StatusNotFound, errkind.NotFound},
+		{errkind.InvalidArgument, http.StatusBadRequest, errkind.InvalidArgument},
+		{errkind.Unauthenticated, http.StatusUnauthorized, errkind.Unauthenticated},
+		{errkind.PermissionDenied, http.StatusForbidden, errkind.PermissionDenied},
+		{errkind.AlreadyExists, http.StatusConflict, errkind.Conflict},
+		{errkind.Unknown, http.StatusInternalServerError, errkind.Internal},
+		{errkind.Unavailable, http.StatusServiceUnavailable, errkind.Unavailable},
+		{"custom", http.StatusInternalServerError, errkind.Internal},
+	} {
+		if want, have := tc.status, errkind.HTTPStatus(tc.kind); want != have {
+			t.Errorf("%s: want %d, have %d", tc.kind, want, have)
+		}
+		if want, have := tc.back, errkind.FromHTTPStatus(tc.status); want != have {
+			t.Errorf("%d: want %q, have %q", tc.status, want, have)
+		}
+	}
+	for status, want := range map[int]errkind.Kind{
+		http.StatusMethodNotAllowed:    errkind.Unimplemented,
+		http.StatusTeapot:              errkind.InvalidArgument,
+		http.StatusBadGateway:          errkind.Unavailable,
+		http.StatusInsufficientStorage: errkind.Internal,
+		http.StatusFound:               errkind.Unknown,
+	} {
+		if have := errkind.FromHTTPStatus(status); want != have {
+			t.Errorf("%d: want %q, have %q", status, want, have)
+		}
+	}
+}
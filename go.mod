@@ -6,6 +6,7 @@ require (
 	github.com/go-kit/kit v0.12.0
 	github.com/nats-io/nats-server/v2 v2.5.0
 	github.com/nats-io/nats.go v1.12.1
+	google.golang.org/genproto v0.0.0-20210917145530-b395a37504d4
 	google.golang.org/grpc v1.40.0
 	google.golang.org/protobuf v1.27.1
 )
@@ -24,5 +25,4 @@ require (
 	golang.org/x/sys v0.0.0-20210917161153-d61c044b1678 // indirect
 	golang.org/x/text v0.3.7 // indirect
 	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac // indirect
-	google.golang.org/genproto v0.0.0-20210917145530-b395a37504d4 // indirect
 )
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/RangelReale/go-kit-typed/ctxmeta"
 	"google.golang.org/grpc/metadata"
 )
 
 type metaContext string
 
+var (
+	correlationID           = ctxmeta.StringKey("correlation-id")
+	correlationIDPropagator = ctxmeta.NewPropagator(correlationID)
+)
+
 const (
-	correlationID     metaContext = "correlation-id"
 	responseHDR       metaContext = "my-response-header"
 	responseTRLR      metaContext = "my-response-trailer"
 	correlationIDTRLR metaContext = "correlation-id-consumed"
@@ -18,13 +23,7 @@ const (
 
 /* client before functions */
 
-func injectCorrelationID(ctx context.Context, md *metadata.MD) context.Context {
-	if hdr, ok := ctx.Value(correlationID).(string); ok {
-		fmt.Printf("\tClient found correlationID %q in context, set metadata header\n", hdr)
-		(*md)[string(correlationID)] = append((*md)[string(correlationID)], hdr)
-	}
-	return ctx
-}
+var injectCorrelationID = correlationIDPropagator.GRPCClientBefore()
 
 func displayClientRequestHeaders(ctx context.Context, md *metadata.MD) context.Context {
 	if len(*md) > 0 {
@@ -38,14 +37,7 @@ func displayClientRequestHeaders(ctx context.Context, md *metadata.MD) context.C
 
 /* server before functions */
 
-func extractCorrelationID(ctx context.Context, md metadata.MD) context.Context {
-	if hdr, ok := md[string(correlationID)]; ok {
-		cID := hdr[len(hdr)-1]
-		ctx = context.WithValue(ctx, correlationID, cID)
-		fmt.Printf("\tServer received correlationID %q in metadata header, set context\n", cID)
-	}
-	return ctx
-}
+var extractCorrelationID = correlationIDPropagator.GRPCServerBefore()
 
 func displayServerRequestHeaders(ctx context.Context, md metadata.MD) context.Context {
 	if len(md) > 0 {
@@ -80,7 +72,7 @@ func injectResponseTrailer(ctx context.Context, _ *metadata.MD, md *metadata.MD)
 }
 
 func injectConsumedCorrelationID(ctx context.Context, _ *metadata.MD, md *metadata.MD) context.Context {
-	if hdr, ok := ctx.Value(correlationID).(string); ok {
+	if hdr, ok := correlationID.From(ctx); ok {
 		fmt.Printf("\tServer found correlationID %q in context, set consumed trailer\n", hdr)
 		*md = metadata.Join(*md, metadata.Pairs(string(correlationIDTRLR), hdr))
 	}
@@ -130,7 +122,7 @@ func extractConsumedCorrelationID(ctx context.Context, _ metadata.MD, md metadat
 /* CorrelationID context handlers */
 
 func SetCorrelationID(ctx context.Context, v string) context.Context {
-	return context.WithValue(ctx, correlationID, v)
+	return correlationID.With(ctx, v)
 }
 
 func GetConsumedCorrelationID(ctx context.Context) string {
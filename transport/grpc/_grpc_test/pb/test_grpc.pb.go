@@ -19,6 +19,9 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TestClient interface {
 	Test(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (*TestResponse, error)
+	ServerStream(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (Test_ServerStreamClient, error)
+	ClientStream(ctx context.Context, opts ...grpc.CallOption) (Test_ClientStreamClient, error)
+	BidiStream(ctx context.Context, opts ...grpc.CallOption) (Test_BidiStreamClient, error)
 }
 
 type testClient struct {
@@ -38,11 +41,111 @@ func (c *testClient) Test(ctx context.Context, in *TestRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *testClient) ServerStream(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (Test_ServerStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Test_ServiceDesc.Streams[0], "/pb.Test/ServerStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testServerStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Test_ServerStreamClient interface {
+	Recv() (*TestResponse, error)
+	grpc.ClientStream
+}
+
+type testServerStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *testServerStreamClient) Recv() (*TestResponse, error) {
+	m := new(TestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *testClient) ClientStream(ctx context.Context, opts ...grpc.CallOption) (Test_ClientStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Test_ServiceDesc.Streams[1], "/pb.Test/ClientStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testClientStreamClient{stream}
+	return x, nil
+}
+
+type Test_ClientStreamClient interface {
+	Send(*TestRequest) error
+	CloseAndRecv() (*TestResponse, error)
+	grpc.ClientStream
+}
+
+type testClientStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *testClientStreamClient) Send(m *TestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *testClientStreamClient) CloseAndRecv() (*TestResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *testClient) BidiStream(ctx context.Context, opts ...grpc.CallOption) (Test_BidiStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Test_ServiceDesc.Streams[2], "/pb.Test/BidiStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testBidiStreamClient{stream}
+	return x, nil
+}
+
+type Test_BidiStreamClient interface {
+	Send(*TestRequest) error
+	Recv() (*TestResponse, error)
+	grpc.ClientStream
+}
+
+type testBidiStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *testBidiStreamClient) Send(m *TestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *testBidiStreamClient) Recv() (*TestResponse, error) {
+	m := new(TestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TestServer is the server API for Test service.
 // All implementations must embed UnimplementedTestServer
 // for forward compatibility
 type TestServer interface {
 	Test(context.Context, *TestRequest) (*TestResponse, error)
+	ServerStream(*TestRequest, Test_ServerStreamServer) error
+	ClientStream(Test_ClientStreamServer) error
+	BidiStream(Test_BidiStreamServer) error
 	mustEmbedUnimplementedTestServer()
 }
 
@@ -53,6 +156,15 @@ type UnimplementedTestServer struct {
 func (UnimplementedTestServer) Test(context.Context, *TestRequest) (*TestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Test not implemented")
 }
+func (UnimplementedTestServer) ServerStream(*TestRequest, Test_ServerStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ServerStream not implemented")
+}
+func (UnimplementedTestServer) ClientStream(Test_ClientStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClientStream not implemented")
+}
+func (UnimplementedTestServer) BidiStream(Test_BidiStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method BidiStream not implemented")
+}
 func (UnimplementedTestServer) mustEmbedUnimplementedTestServer() {}
 
 // UnsafeTestServer may be embedded to opt out of forward compatibility for this service.
@@ -84,6 +196,79 @@ func _Test_Test_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Test_ServerStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestServer).ServerStream(m, &testServerStreamServer{stream})
+}
+
+type Test_ServerStreamServer interface {
+	Send(*TestResponse) error
+	grpc.ServerStream
+}
+
+type testServerStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *testServerStreamServer) Send(m *TestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Test_ClientStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TestServer).ClientStream(&testClientStreamServer{stream})
+}
+
+type Test_ClientStreamServer interface {
+	SendAndClose(*TestResponse) error
+	Recv() (*TestRequest, error)
+	grpc.ServerStream
+}
+
+type testClientStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *testClientStreamServer) SendAndClose(m *TestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *testClientStreamServer) Recv() (*TestRequest, error) {
+	m := new(TestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Test_BidiStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TestServer).BidiStream(&testBidiStreamServer{stream})
+}
+
+type Test_BidiStreamServer interface {
+	Send(*TestResponse) error
+	Recv() (*TestRequest, error)
+	grpc.ServerStream
+}
+
+type testBidiStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *testBidiStreamServer) Send(m *TestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *testBidiStreamServer) Recv() (*TestRequest, error) {
+	m := new(TestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Test_ServiceDesc is the grpc.ServiceDesc for Test service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -96,6 +281,23 @@ var Test_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Test_Test_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerStream",
+			Handler:       _Test_ServerStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientStream",
+			Handler:       _Test_ClientStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BidiStream",
+			Handler:       _Test_BidiStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "test.proto",
 }
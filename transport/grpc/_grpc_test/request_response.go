@@ -23,3 +23,19 @@ func decodeResponse(ctx context.Context, resp interface{}) (*TestResponse, error
 	r := resp.(*pb.TestResponse)
 	return &TestResponse{V: r.V, Ctx: ctx}, nil
 }
+
+func encodeProtoRequest(ctx context.Context, r TestRequest) (*pb.TestRequest, error) {
+	return &pb.TestRequest{A: r.A, B: r.B}, nil
+}
+
+func decodeProtoRequest(ctx context.Context, r *pb.TestRequest) (TestRequest, error) {
+	return TestRequest{A: r.A, B: r.B}, nil
+}
+
+func encodeProtoResponse(ctx context.Context, r *TestResponse) (*pb.TestResponse, error) {
+	return &pb.TestResponse{V: r.V}, nil
+}
+
+func decodeProtoResponse(ctx context.Context, r *pb.TestResponse) (*TestResponse, error) {
+	return &TestResponse{V: r.V, Ctx: ctx}, nil
+}
@@ -33,20 +33,16 @@ func makeTestEndpoint(svc Service) endpoint.Endpoint[TestRequest, *TestResponse]
 type serverBinding struct {
 	pb.UnimplementedTestServer
 
-	test gokitgrpctransport.Handler
+	test func(context.Context, *pb.TestRequest) (*pb.TestResponse, error)
 }
 
 func (b *serverBinding) Test(ctx context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
-	_, response, err := b.test.ServeGRPC(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return response.(*pb.TestResponse), nil
+	return b.test(ctx, req)
 }
 
 func NewBinding(svc Service) *serverBinding {
 	return &serverBinding{
-		test: grpctransport.NewServer(
+		test: grpctransport.ServerFunc[*pb.TestRequest, *pb.TestResponse](grpctransport.NewServer(
 			makeTestEndpoint(svc),
 			decodeRequest,
 			encodeResponse,
@@ -65,6 +61,6 @@ func NewBinding(svc Service) *serverBinding {
 				displayServerResponseHeaders,
 				displayServerResponseTrailers,
 			),
-		),
+		)),
 	}
 }
@@ -15,3 +15,13 @@ type TestResponse struct {
 	Ctx context.Context
 	V   string
 }
+
+// StreamService is the streaming part of the test service.
+type StreamService interface {
+	// Repeat sends "a = i" for i from 1 to b.
+	Repeat(ctx context.Context, a string, b int64, send func(string) error) error
+	// Join joins the "a = b" of all the requests.
+	Join(ctx context.Context, recv func() (TestRequest, error)) (string, error)
+	// Echo sends "a = b" for each request.
+	Echo(ctx context.Context, recv func() (TestRequest, error), send func(string) error) error
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+func (service) Repeat(ctx context.Context, a string, b int64, send func(string) error) error {
+	if b < 0 {
+		return status.Error(codes.InvalidArgument, "negative count")
+	}
+	for i := int64(1); i <= b; i++ {
+		if err := send(fmt.Sprintf("%s = %d", a, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service) Join(ctx context.Context, recv func() (TestRequest, error)) (string, error) {
+	var values []string
+	for {
+		req, err := recv()
+		if err == io.EOF {
+			return strings.Join(values, ", "), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if req.A == "" {
+			return "", errors.New("empty name")
+		}
+		values = append(values, fmt.Sprintf("%s = %d", req.A, req.B))
+	}
+}
+
+func (service) Echo(ctx context.Context, recv func() (TestRequest, error), send func(string) error) error {
+	for {
+		req, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := send(fmt.Sprintf("%s = %d", req.A, req.B)); err != nil {
+			return err
+		}
+	}
+}
+
+func NewStreamService() StreamService {
+	return service{}
+}
+
+func makeRepeatEndpoint(svc StreamService) endpoint.ServerStreamEndpoint[TestRequest, *TestResponse] {
+	return func(ctx context.Context, req TestRequest, send func(*TestResponse) error) error {
+		return svc.Repeat(ctx, req.A, req.B, func(v string) error {
+			return send(&TestResponse{V: v})
+		})
+	}
+}
+
+func makeJoinEndpoint(svc StreamService) endpoint.ClientStreamEndpoint[TestRequest, *TestResponse] {
+	return func(ctx context.Context, recv func() (TestRequest, error)) (*TestResponse, error) {
+		v, err := svc.Join(ctx, recv)
+		if err != nil {
+			return nil, err
+		}
+		return &TestResponse{V: v}, nil
+	}
+}
+
+func makeEchoEndpoint(svc StreamService) endpoint.BidiStreamEndpoint[TestRequest, *TestResponse] {
+	return func(ctx context.Context, recv func() (TestRequest, error), send func(*TestResponse) error) error {
+		return svc.Echo(ctx, recv, func(v string) error {
+			return send(&TestResponse{V: v})
+		})
+	}
+}
+
+type streamServerBinding struct {
+	*serverBinding
+
+	repeat *grpctransport.ServerStreamServer[*pb.TestRequest, TestRequest, *TestResponse, *pb.TestResponse]
+	join   *grpctransport.ClientStreamServer[*pb.TestRequest, TestRequest, *TestResponse, *pb.TestResponse]
+	echo   *grpctransport.BidiStreamServer[*pb.TestRequest, TestRequest, *TestResponse, *pb.TestResponse]
+}
+
+func (b *streamServerBinding) ServerStream(req *pb.TestRequest, stream pb.Test_ServerStreamServer) error {
+	return b.repeat.ServeGRPCStream(req, stream)
+}
+
+func (b *streamServerBinding) ClientStream(stream pb.Test_ClientStreamServer) error {
+	return b.join.ServeGRPCStream(stream)
+}
+
+func (b *streamServerBinding) BidiStream(stream pb.Test_BidiStreamServer) error {
+	return b.echo.ServeGRPCStream(stream)
+}
+
+// NewStreamBinding returns a binding for the unary and streaming methods.
+func NewStreamBinding(svc Service, streamSvc StreamService) pb.TestServer {
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerBefore(
+			extractCorrelationID,
+		),
+		grpctransport.ServerAfter(
+			injectResponseHeader,
+			injectResponseTrailer,
+			injectConsumedCorrelationID,
+		),
+	}
+	return &streamServerBinding{
+		serverBinding: NewBinding(svc),
+		repeat: grpctransport.NewServerStreamServer(
+			makeRepeatEndpoint(streamSvc),
+			decodeProtoRequest,
+			encodeProtoResponse,
+			options...,
+		),
+		join: grpctransport.NewClientStreamServer(
+			makeJoinEndpoint(streamSvc),
+			decodeProtoRequest,
+			encodeProtoResponse,
+			options...,
+		),
+		echo: grpctransport.NewBidiStreamServer(
+			makeEchoEndpoint(streamSvc),
+			decodeProtoRequest,
+			encodeProtoResponse,
+			options...,
+		),
+	}
+}
+
+// StreamClient holds the client endpoints of the streaming methods.
+type StreamClient struct {
+	Repeat endpoint.ServerStreamEndpoint[TestRequest, *TestResponse]
+	Join   endpoint.ClientStreamEndpoint[TestRequest, *TestResponse]
+	Echo   endpoint.BidiStreamEndpoint[TestRequest, *TestResponse]
+}
+
+func NewStreamClient(cc grpc.ClientConnInterface, options ...grpctransport.ClientOption) StreamClient {
+	options = append([]grpctransport.ClientOption{
+		grpctransport.ClientBefore(
+			injectCorrelationID,
+		),
+		grpctransport.ClientAfter(
+			extractConsumedCorrelationID,
+		),
+	}, options...)
+	return StreamClient{
+		Repeat: grpctransport.NewServerStreamClient(
+			cc, "pb.Test", "ServerStream", encodeProtoRequest, decodeProtoResponse, options...,
+		).Endpoint(),
+		Join: grpctransport.NewClientStreamClient(
+			cc, "pb.Test", "ClientStream", encodeProtoRequest, decodeProtoResponse, options...,
+		).Endpoint(),
+		Echo: grpctransport.NewBidiStreamClient(
+			cc, "pb.Test", "BidiStream", encodeProtoRequest, decodeProtoResponse, options...,
+		).Endpoint(),
+	}
+}
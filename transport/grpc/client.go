@@ -1,14 +1,18 @@
 package grpc
 
 import (
+	"context"
+
 	"github.com/RangelReale/go-kit-typed/endpoint"
 	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 // Client wraps a URL and provides a method that implements endpoint.Endpoint.
 type Client[Req any, Resp any] struct {
-	client *gokitgrpctransport.Client
+	client     *gokitgrpctransport.Client
+	finalizers []ClientFinalizerTypedFunc[Req, Resp]
 }
 
 // NewClient constructs a usable Client for a single remote endpoint.
@@ -23,21 +27,96 @@ func NewClient[Req any, Resp any](
 	grpcReply interface{},
 	options ...gokitgrpctransport.ClientOption,
 ) *Client[Req, Resp] {
-	client := gokitgrpctransport.NewClient(
-		cc,
-		serviceName,
-		method,
+	return newClient[Req, Resp](cc, serviceName, method,
 		EncodeRequestFuncReverseAdapter(enc),
 		DecodeResponseFuncReverseAdapter(dec),
-		grpcReply,
-		options...)
+		grpcReply, options)
+}
+
+// NewClientStdEnc constructs a usable Client for a single remote endpoint,
+// using the non-typed encoder.
+func NewClientStdEnc[Req any, Resp any](
+	cc *grpc.ClientConn,
+	serviceName string,
+	method string,
+	enc gokitgrpctransport.EncodeRequestFunc,
+	dec DecodeResponseFunc[Resp],
+	grpcReply interface{},
+	options ...gokitgrpctransport.ClientOption,
+) *Client[Req, Resp] {
+	return newClient[Req, Resp](cc, serviceName, method,
+		enc,
+		DecodeResponseFuncReverseAdapter(dec),
+		grpcReply, options)
+}
+
+// NewClientStdDec constructs a usable Client for a single remote endpoint,
+// using the non-typed decoder.
+func NewClientStdDec[Req any, Resp any](
+	cc *grpc.ClientConn,
+	serviceName string,
+	method string,
+	enc EncodeRequestFunc[Req],
+	dec gokitgrpctransport.DecodeResponseFunc,
+	grpcReply interface{},
+	options ...gokitgrpctransport.ClientOption,
+) *Client[Req, Resp] {
+	return newClient[Req, Resp](cc, serviceName, method,
+		EncodeRequestFuncReverseAdapter(enc),
+		dec,
+		grpcReply, options)
+}
+
+// NewProtoClient constructs a usable Client for a single remote endpoint, using
+// encoders and decoders typed by the gRPC request and response messages, so a
+// mismatched message type is a compile error. PResp must be a pointer to the
+// protobuf message of the RPC response type. It accepts the options of
+// NativeClient.
+func NewProtoClient[Req any, PReq proto.Message, PResp proto.Message, Resp any](
+	cc *grpc.ClientConn,
+	serviceName string,
+	method string,
+	enc ProtoEncodeRequestFunc[Req, PReq],
+	dec ProtoDecodeResponseFunc[PResp, Resp],
+	options ...ClientOption,
+) *Client[Req, Resp] {
+	return NewClient(cc, serviceName, method, ProtoEncodeRequestFuncAdapter(enc),
+		ProtoDecodeResponseFuncAdapter(dec), newMessageFunc[PResp]("NewProtoClient")(),
+		newClientOptions(options).gokitOptions()...)
+}
+
+func newClient[Req any, Resp any](cc *grpc.ClientConn, serviceName string, method string,
+	enc gokitgrpctransport.EncodeRequestFunc, dec gokitgrpctransport.DecodeResponseFunc, grpcReply interface{},
+	options []gokitgrpctransport.ClientOption) *Client[Req, Resp] {
 	return &Client[Req, Resp]{
-		client: client,
+		client: gokitgrpctransport.NewClient(cc, serviceName, method, enc, dec, grpcReply, options...),
 	}
 }
 
+// Finalizer adds functions which are executed at the end of every call, like
+// ClientFinalizer, and also receive the request, the decoded response and the
+// error of the call. They are executed after the ClientFinalizer functions,
+// and must be set before the endpoint is created.
+func (c *Client[Req, Resp]) Finalizer(f ...ClientFinalizerTypedFunc[Req, Resp]) *Client[Req, Resp] {
+	c.finalizers = append(c.finalizers, f...)
+	return c
+}
+
 // Endpoint returns a usable endpoint that will invoke the gRPC specified by the
-// client.
+// client. Errors with a gRPC status have the errkind kind of their code, so
+// errors.Is(err, errkind.NotFound) works for errors returned by servers, and
+// their google.rpc error details are available with errkind.DetailOf.
 func (c Client[Req, Resp]) Endpoint() endpoint.Endpoint[Req, Resp] {
-	return endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	e := endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	return func(ctx context.Context, request Req) (response Resp, err error) {
+		if len(c.finalizers) > 0 {
+			defer func() {
+				for _, f := range c.finalizers {
+					f(ctx, request, response, err)
+				}
+			}()
+		}
+		response, err = e(ctx, request)
+		return response, decodeErrorKind(err)
+	}
 }
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+)
+
+// ClientOption sets an optional parameter for clients.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	before    []gokitgrpctransport.ClientRequestFunc
+	after     []gokitgrpctransport.ClientResponseFunc
+	finalizer []gokitgrpctransport.ClientFinalizerFunc
+}
+
+func newClientOptions(options []ClientOption) clientOptions {
+	var copt clientOptions
+	for _, opt := range options {
+		opt(&copt)
+	}
+	return copt
+}
+
+// gokitOptions returns the options for a Go kit client.
+func (c clientOptions) gokitOptions() []gokitgrpctransport.ClientOption {
+	var ret []gokitgrpctransport.ClientOption
+	if len(c.before) > 0 {
+		ret = append(ret, gokitgrpctransport.ClientBefore(c.before...))
+	}
+	if len(c.after) > 0 {
+		ret = append(ret, gokitgrpctransport.ClientAfter(c.after...))
+	}
+	if len(c.finalizer) > 0 {
+		ret = append(ret, gokitgrpctransport.ClientFinalizer(c.finalizer...))
+	}
+	return ret
+}
+
+// ClientBefore sets the RequestFuncs that are applied to the outgoing gRPC
+// request before it's invoked.
+func ClientBefore(before ...gokitgrpctransport.ClientRequestFunc) ClientOption {
+	return func(c *clientOptions) { c.before = append(c.before, before...) }
+}
+
+// ClientAfter sets the ClientResponseFuncs that are applied to the incoming
+// gRPC response prior to it being decoded. This is useful for obtaining
+// response metadata and adding onto the context prior to decoding.
+func ClientAfter(after ...gokitgrpctransport.ClientResponseFunc) ClientOption {
+	return func(c *clientOptions) { c.after = append(c.after, after...) }
+}
+
+// ClientFinalizer is executed at the end of every gRPC request.
+// By default, no finalizer is registered.
+func ClientFinalizer(f ...gokitgrpctransport.ClientFinalizerFunc) ClientOption {
+	return func(c *clientOptions) { c.finalizer = append(c.finalizer, f...) }
+}
+
+// ClientFinalizerTypedFunc is a typed client finalizer, which receives the
+// request, the decoded response and the error of the call, if any.
+type ClientFinalizerTypedFunc[Req any, Resp any] func(ctx context.Context, request Req, response Resp, err error)
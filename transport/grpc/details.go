@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// withErrorDetails returns the status with the errkind details of err as
+// google.rpc error details. If the details can't be added, the status is
+// returned as is.
+func withErrorDetails(st *status.Status, err error) *status.Status {
+	details := errkind.DetailsOf(err)
+	if len(details) == 0 {
+		return st
+	}
+	msgs := make([]protoiface.MessageV1, 0, len(details))
+	for _, detail := range details {
+		if msg := encodeErrorDetail(detail); msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	ret, derr := st.WithDetails(msgs...)
+	if derr != nil {
+		return st
+	}
+	return ret
+}
+
+func encodeErrorDetail(detail errkind.Detail) protoiface.MessageV1 {
+	switch d := detail.(type) {
+	case errkind.BadRequest:
+		msg := &errdetails.BadRequest{}
+		for _, v := range d.FieldViolations {
+			msg.FieldViolations = append(msg.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		return msg
+	case errkind.RetryInfo:
+		return &errdetails.RetryInfo{RetryDelay: durationpb.New(d.RetryDelay)}
+	case errkind.ErrorInfo:
+		return &errdetails.ErrorInfo{Reason: d.Reason, Domain: d.Domain, Metadata: d.Metadata}
+	}
+	return nil
+}
+
+// decodeErrorDetails returns the google.rpc error details of the status which
+// have an errkind detail. Other details are ignored.
+func decodeErrorDetails(st *status.Status) []errkind.Detail {
+	var ret []errkind.Detail
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			br := errkind.BadRequest{}
+			for _, v := range d.GetFieldViolations() {
+				br.FieldViolations = append(br.FieldViolations, errkind.FieldViolation{
+					Field:       v.GetField(),
+					Description: v.GetDescription(),
+				})
+			}
+			ret = append(ret, br)
+		case *errdetails.RetryInfo:
+			ret = append(ret, errkind.RetryInfo{RetryDelay: d.GetRetryDelay().AsDuration()})
+		case *errdetails.ErrorInfo:
+			ret = append(ret, errkind.ErrorInfo{Reason: d.GetReason(), Domain: d.GetDomain(), Metadata: d.GetMetadata()})
+		}
+	}
+	return ret
+}
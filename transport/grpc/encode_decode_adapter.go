@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+)
+
+// DecodeRequestFuncAdapter is an adapter from the non-generic DecodeRequestFunc function
+func DecodeRequestFuncAdapter[Req any](f gokitgrpctransport.DecodeRequestFunc) DecodeRequestFunc[Req] {
+	return func(ctx context.Context, i interface{}) (Req, error) {
+		return util.ReturnTypeWithError[Req](f(ctx, i))
+	}
+}
+
+// EncodeRequestFuncAdapter is an adapter from the non-generic EncodeRequestFunc function
+func EncodeRequestFuncAdapter[Req any](f gokitgrpctransport.EncodeRequestFunc) EncodeRequestFunc[Req] {
+	return func(ctx context.Context, req Req) (interface{}, error) {
+		return f(ctx, req)
+	}
+}
+
+// EncodeResponseFuncAdapter is an adapter from the non-generic EncodeResponseFunc function
+func EncodeResponseFuncAdapter[Resp any](f gokitgrpctransport.EncodeResponseFunc) EncodeResponseFunc[Resp] {
+	return func(ctx context.Context, resp Resp) (interface{}, error) {
+		return f(ctx, resp)
+	}
+}
+
+// DecodeResponseFuncAdapter is an adapter from the non-generic DecodeResponseFunc function
+func DecodeResponseFuncAdapter[Resp any](f gokitgrpctransport.DecodeResponseFunc) DecodeResponseFunc[Resp] {
+	return func(ctx context.Context, i interface{}) (Resp, error) {
+		return util.ReturnTypeWithError[Resp](f(ctx, i))
+	}
+}
@@ -0,0 +1,40 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+func TestAdapters(t *testing.T) {
+	ctx := context.Background()
+	echo := func(_ context.Context, i interface{}) (interface{}, error) { return i, nil }
+
+	req, err := grpctransport.DecodeRequestFuncAdapter[string](echo)(ctx, "req")
+	if err != nil || req != "req" {
+		t.Errorf("want %q, have %q (%v)", "req", req, err)
+	}
+	if _, err := grpctransport.DecodeRequestFuncAdapter[string](echo)(ctx, 12); err != util.ErrParameterInvalidType {
+		t.Errorf("want %v, have %v", util.ErrParameterInvalidType, err)
+	}
+
+	msg, err := grpctransport.EncodeRequestFuncAdapter[string](echo)(ctx, "msg")
+	if err != nil || msg != "msg" {
+		t.Errorf("want %q, have %q (%v)", "msg", msg, err)
+	}
+
+	msg, err = grpctransport.EncodeResponseFuncAdapter[string](echo)(ctx, "resp")
+	if err != nil || msg != "resp" {
+		t.Errorf("want %q, have %q (%v)", "resp", msg, err)
+	}
+
+	resp, err := grpctransport.DecodeResponseFuncAdapter[int](echo)(ctx, 12)
+	if err != nil || resp != 12 {
+		t.Errorf("want %d, have %d (%v)", 12, resp, err)
+	}
+	if _, err := grpctransport.DecodeResponseFuncAdapter[int](echo)(ctx, "12"); err != util.ErrParameterInvalidType {
+		t.Errorf("want %v, have %v", util.ErrParameterInvalidType, err)
+	}
+}
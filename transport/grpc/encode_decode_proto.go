@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoDecodeRequestFunc extracts a user-domain request object from a gRPC
+// request message, with the protobuf message type known at compile time.
+type ProtoDecodeRequestFunc[PReq proto.Message, Req any] func(context.Context, PReq) (request Req, err error)
+
+// ProtoEncodeRequestFunc encodes the passed request object into the gRPC
+// request message, with the protobuf message type known at compile time.
+type ProtoEncodeRequestFunc[Req any, PReq proto.Message] func(context.Context, Req) (request PReq, err error)
+
+// ProtoEncodeResponseFunc encodes the passed response object to the gRPC
+// response message, with the protobuf message type known at compile time.
+type ProtoEncodeResponseFunc[Resp any, PResp proto.Message] func(context.Context, Resp) (response PResp, err error)
+
+// ProtoDecodeResponseFunc extracts a user-domain response object from a gRPC
+// response message, with the protobuf message type known at compile time.
+type ProtoDecodeResponseFunc[PResp proto.Message, Resp any] func(context.Context, PResp) (response Resp, err error)
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoDecodeRequestFuncAdapter is an adapter from ProtoDecodeRequestFunc to
+// DecodeRequestFunc. A request message of another type returns
+// util.ErrParameterInvalidType.
+func ProtoDecodeRequestFuncAdapter[PReq proto.Message, Req any](f ProtoDecodeRequestFunc[PReq, Req]) DecodeRequestFunc[Req] {
+	return func(ctx context.Context, i interface{}) (Req, error) {
+		return util.CallTypeResponseWithError[PReq, Req](i, func(r PReq) (Req, error) {
+			return f(ctx, r)
+		})
+	}
+}
+
+// ProtoEncodeRequestFuncAdapter is an adapter from ProtoEncodeRequestFunc to
+// EncodeRequestFunc.
+func ProtoEncodeRequestFuncAdapter[Req any, PReq proto.Message](f ProtoEncodeRequestFunc[Req, PReq]) EncodeRequestFunc[Req] {
+	return func(ctx context.Context, r Req) (interface{}, error) {
+		return f(ctx, r)
+	}
+}
+
+// ProtoEncodeResponseFuncAdapter is an adapter from ProtoEncodeResponseFunc to
+// EncodeResponseFunc.
+func ProtoEncodeResponseFuncAdapter[Resp any, PResp proto.Message](f ProtoEncodeResponseFunc[Resp, PResp]) EncodeResponseFunc[Resp] {
+	return func(ctx context.Context, r Resp) (interface{}, error) {
+		return f(ctx, r)
+	}
+}
+
+// ProtoDecodeResponseFuncAdapter is an adapter from ProtoDecodeResponseFunc to
+// DecodeResponseFunc. A response message of another type returns
+// util.ErrParameterInvalidType.
+func ProtoDecodeResponseFuncAdapter[PResp proto.Message, Resp any](f ProtoDecodeResponseFunc[PResp, Resp]) DecodeResponseFunc[Resp] {
+	return func(ctx context.Context, i interface{}) (Resp, error) {
+		return util.CallTypeResponseWithError[PResp, Resp](i, func(r PResp) (Resp, error) {
+			return f(ctx, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+func TestProtoAdapters(t *testing.T) {
+	dec := grpctransport.ProtoDecodeRequestFuncAdapter(nativeDecodeRequest)
+	req, err := dec(context.Background(), &pb.TestRequest{A: "a", B: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (nativeRequest{A: "a", B: 1}), req; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if _, err := dec(context.Background(), &pb.TestResponse{}); err != util.ErrParameterInvalidType {
+		t.Errorf("want %v, have %v", util.ErrParameterInvalidType, err)
+	}
+
+	enc := grpctransport.ProtoEncodeResponseFuncAdapter(nativeEncodeResponse)
+	resp, err := enc(context.Background(), nativeResponse{V: "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "v", resp.(*pb.TestResponse).V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	decResp := grpctransport.ProtoDecodeResponseFuncAdapter(nativeDecodeResponse)
+	if _, err := decResp(context.Background(), &pb.TestRequest{}); err != util.ErrParameterInvalidType {
+		t.Errorf("want %v, have %v", util.ErrParameterInvalidType, err)
+	}
+}
+
+func TestProtoServerClient(t *testing.T) {
+	cc := startBufconnServer(t, &wrapperBinding{
+		test: grpctransport.ServerFunc[*pb.TestRequest, *pb.TestResponse](
+			grpctransport.NewProtoServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse)),
+	})
+	client := grpctransport.NewProtoClient(cc, "pb.Test", "Test", nativeEncodeRequest, nativeDecodeResponse)
+
+	resp, err := client.Endpoint()(context.Background(), nativeRequest{A: "answer", B: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "answer = 42", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
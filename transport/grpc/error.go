@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"google.golang.org/grpc/status"
+)
+
+// statusError is a gRPC status error returned by clients, which has the
+// errkind kind of its code, and the errkind details of its google.rpc error
+// details. It keeps the status available to status.FromError.
+type statusError struct {
+	err    error
+	status *status.Status
+}
+
+func (e statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e statusError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus returns the status of the error.
+func (e statusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// ErrorKind implements errkind.Kinder.
+func (e statusError) ErrorKind() errkind.Kind {
+	return errkind.FromGRPCCode(e.status.Code())
+}
+
+// ErrorDetails implements errkind.Detailer.
+func (e statusError) ErrorDetails() []errkind.Detail {
+	return decodeErrorDetails(e.status)
+}
+
+// Is reports whether target is the errkind kind of the error.
+func (e statusError) Is(target error) bool {
+	kind, ok := target.(errkind.Kind)
+	return ok && kind == e.ErrorKind()
+}
+
+// decodeErrorKind returns errors with a gRPC status as a statusError.
+func decodeErrorKind(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(statusError); ok {
+		return err
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return statusError{err: err, status: s}
+}
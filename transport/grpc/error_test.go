@@ -0,0 +1,116 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+func kindEndpoint(_ context.Context, req nativeRequest) (nativeResponse, error) {
+	return nativeResponse{}, errkind.New(errkind.Kind(req.A), "dang")
+}
+
+type kindStreamBinding struct {
+	pb.UnimplementedTestServer
+	stream *grpctransport.ServerStreamServer[*pb.TestRequest, nativeRequest, nativeResponse, *pb.TestResponse]
+}
+
+func (b *kindStreamBinding) ServerStream(req *pb.TestRequest, stream pb.Test_ServerStreamServer) error {
+	return b.stream.ServeGRPCStream(req, stream)
+}
+
+func TestErrorKindRoundTrip(t *testing.T) {
+	cc := startBufconnServer(t, &nativeBinding{
+		test: grpctransport.NewNativeServer(kindEndpoint, nativeDecodeRequest, nativeEncodeResponse),
+	})
+	clients := map[string]func(context.Context, nativeRequest) (nativeResponse, error){
+		"client": grpctransport.NewProtoClient(cc, "pb.Test", "Test", nativeEncodeRequest,
+			nativeDecodeResponse).Endpoint(),
+		"native client": grpctransport.NewNativeClient(cc, "pb.Test", "Test", nativeEncodeRequest,
+			nativeDecodeResponse).Endpoint(),
+	}
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			for _, kind := range []errkind.Kind{errkind.NotFound, errkind.Conflict, errkind.Unauthenticated} {
+				_, err := client(context.Background(), nativeRequest{A: string(kind)})
+				if !errors.Is(err, kind) {
+					t.Errorf("want errors.Is %q, have %v", kind, err)
+				}
+				if want, have := errkind.GRPCCode(kind), status.Code(err); want != have {
+					t.Errorf("want %s, have %s", want, have)
+				}
+				if want, have := "dang", status.Convert(err).Message(); want != have {
+					t.Errorf("want %q, have %q", want, have)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorKindServerStream(t *testing.T) {
+	cc := startBufconnServer(t, &kindStreamBinding{
+		stream: grpctransport.NewServerStreamServer(
+			func(ctx context.Context, req nativeRequest, _ func(nativeResponse) error) error {
+				_, err := kindEndpoint(ctx, req)
+				return err
+			},
+			nativeDecodeRequest, nativeEncodeResponse),
+	})
+	client := grpctransport.NewServerStreamClient(cc, "pb.Test", "ServerStream", nativeEncodeRequest,
+		nativeDecodeResponse)
+
+	err := client.Endpoint()(context.Background(), nativeRequest{A: string(errkind.Unavailable)},
+		func(nativeResponse) error { return nil })
+	if !errors.Is(err, errkind.Unavailable) {
+		t.Errorf("want errors.Is %q, have %v", errkind.Unavailable, err)
+	}
+	if want, have := codes.Unavailable, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestErrorDetailsRoundTrip(t *testing.T) {
+	badRequest := errkind.BadRequest{FieldViolations: []errkind.FieldViolation{
+		{Field: "a", Description: "required"},
+		{Field: "b", Description: "must be positive"},
+	}}
+	retry := errkind.RetryInfo{RetryDelay: 1500 * time.Millisecond}
+	info := errkind.ErrorInfo{Reason: "INVALID", Domain: "test", Metadata: map[string]string{"k": "v"}}
+
+	cc := startBufconnServer(t, &nativeBinding{
+		test: grpctransport.NewNativeServer(
+			func(context.Context, nativeRequest) (nativeResponse, error) {
+				return nativeResponse{}, errkind.WithDetails(errkind.New(errkind.InvalidArgument, "invalid"),
+					badRequest, retry, info)
+			},
+			nativeDecodeRequest, nativeEncodeResponse),
+	})
+	_, err := grpctransport.NewNativeClient(cc, "pb.Test", "Test", nativeEncodeRequest,
+		nativeDecodeResponse).Endpoint()(context.Background(), nativeRequest{})
+
+	if !errors.Is(err, errkind.InvalidArgument) {
+		t.Errorf("want errors.Is %q, have %v", errkind.InvalidArgument, err)
+	}
+	if want, have := []errkind.Detail{badRequest, retry, info}, errkind.DetailsOf(err); !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	br, ok := errkind.DetailOf[errkind.BadRequest](err)
+	if !ok {
+		t.Fatal("want BadRequest detail")
+	}
+	if want, have := "b", br.FieldViolations[1].Field; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := 3, len(status.Convert(err).Details()); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
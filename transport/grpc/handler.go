@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerFunc turns a server into a function with the signature of the methods
+// of the generated gRPC service interfaces, so bindings don't need to call
+// ServeGRPC and assert the reply type. PReq and PResp are the protobuf request
+// and response messages of the RPC.
+//
+// Errors are returned unchanged, as encoded by the error encoder of the server.
+// A reply of a type other than PResp is returned as an Internal error.
+func ServerFunc[PReq any, PResp any](h gokitgrpctransport.Handler) func(context.Context, PReq) (PResp, error) {
+	return func(ctx context.Context, req PReq) (PResp, error) {
+		var resp PResp
+		_, reply, err := h.ServeGRPC(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		resp, ok := reply.(PResp)
+		if !ok {
+			return resp, status.Error(codes.Internal, fmt.Sprintf("invalid reply type %T, expected %T", reply, resp))
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,85 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+type handlerFunc func(ctx context.Context, request interface{}) (context.Context, interface{}, error)
+
+func (f handlerFunc) ServeGRPC(ctx context.Context, request interface{}) (context.Context, interface{}, error) {
+	return f(ctx, request)
+}
+
+func TestServerFunc(t *testing.T) {
+	f := grpctransport.ServerFunc[*pb.TestRequest, *pb.TestResponse](grpctransport.NewServer(
+		nativeEndpoint,
+		func(ctx context.Context, req interface{}) (nativeRequest, error) {
+			return nativeDecodeRequest(ctx, req.(*pb.TestRequest))
+		},
+		func(ctx context.Context, resp nativeResponse) (interface{}, error) {
+			return nativeEncodeResponse(ctx, resp)
+		},
+	))
+
+	resp, err := f(context.Background(), &pb.TestRequest{A: "a", B: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a = 1", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	_, err = f(context.Background(), &pb.TestRequest{A: "a", B: -1})
+	if want, have := codes.InvalidArgument, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestServerFuncErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		handler handlerFunc
+		code    codes.Code
+	}{
+		{
+			name: "plain error",
+			handler: func(ctx context.Context, _ interface{}) (context.Context, interface{}, error) {
+				return ctx, nil, errors.New("dang")
+			},
+			code: codes.Unknown,
+		},
+		{
+			name: "encoded error",
+			handler: func(ctx context.Context, _ interface{}) (context.Context, interface{}, error) {
+				return ctx, nil, status.Error(codes.NotFound, "dang")
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "invalid reply",
+			handler: func(ctx context.Context, _ interface{}) (context.Context, interface{}, error) {
+				return ctx, "reply", nil
+			},
+			code: codes.Internal,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := grpctransport.ServerFunc[*pb.TestRequest, *pb.TestResponse](tc.handler)
+			resp, err := f(context.Background(), &pb.TestRequest{})
+			if want, have := tc.code, status.Code(err); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+			if resp != nil {
+				t.Errorf("want nil response, have %v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MiddlewareUnaryServerInterceptor runs a typed middleware as a unary server
+// interceptor. The request message is decoded with dec, so the middleware
+// receives the domain request, and the response is the message returned by the
+// gRPC handler. PReq and PResp are the request and response messages of the
+// RPC, which are not required to be protobuf messages, so it also runs on the
+// methods of a ProtolessService.
+//
+// The handler is called with the original request message, so changes made by
+// the middleware to the decoded request are not seen by it. Requests of other
+// types than PReq, like the ones of other methods of the server, are passed to
+// the handler without running the middleware. Errors are converted with the
+// DefaultErrorEncoder.
+func MiddlewareUnaryServerInterceptor[PReq any, Req any, PResp any](
+	m endpoint.Middleware[Req, PResp],
+	dec func(context.Context, PReq) (Req, error),
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		preq, ok := req.(PReq)
+		if !ok {
+			return handler(ctx, req)
+		}
+		request, err := dec(ctx, preq)
+		if err != nil {
+			return nil, DefaultErrorEncoder(ctx, err)
+		}
+		e := m(func(ctx context.Context, _ Req) (PResp, error) {
+			var resp PResp
+			reply, err := handler(ctx, preq)
+			if err != nil {
+				return resp, err
+			}
+			resp, ok := reply.(PResp)
+			if !ok {
+				return resp, status.Error(codes.Internal,
+					fmt.Sprintf("invalid reply type %T, expected %T", reply, resp))
+			}
+			return resp, nil
+		})
+		resp, err := e(ctx, request)
+		if err != nil {
+			return nil, DefaultErrorEncoder(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// UnaryClientInterceptorMiddleware returns a middleware which runs unary
+// client interceptors around a typed client endpoint, the first one being the
+// outermost, as with grpc.WithChainUnaryInterceptor. The method is passed to
+// the interceptors as the full gRPC method name, like "/pb.Test/Test".
+//
+// The interceptors receive the domain request, a *Resp as the reply, which is
+// set by the endpoint, and cc, usually the connection of the wrapped client.
+// The call options are ignored, so interceptors which depend on the protobuf
+// messages must be set on the gRPC connection instead. Errors with a gRPC
+// status returned by the interceptors carry its errkind kind, like the ones of
+// the clients.
+func UnaryClientInterceptorMiddleware[Req any, Resp any](cc *grpc.ClientConn, method string,
+	interceptors ...grpc.UnaryClientInterceptor) endpoint.Middleware[Req, Resp] {
+	return func(next endpoint.Endpoint[Req, Resp]) endpoint.Endpoint[Req, Resp] {
+		invoker := func(ctx context.Context, _ string, req, reply interface{}, _ *grpc.ClientConn,
+			_ ...grpc.CallOption) error {
+			request, ok := req.(Req)
+			if !ok {
+				var r Req
+				return fmt.Errorf("invalid request type %T, expected %T", req, r)
+			}
+			response, ok := reply.(*Resp)
+			if !ok {
+				var r *Resp
+				return fmt.Errorf("invalid reply type %T, expected %T", reply, r)
+			}
+			var err error
+			*response, err = next(ctx, request)
+			return err
+		}
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], invoker
+			invoker = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inner, opts...)
+			}
+		}
+		return func(ctx context.Context, request Req) (Resp, error) {
+			var response Resp
+			err := invoker(ctx, method, request, &response, cc)
+			return response, decodeErrorKind(err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/errkind"
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+func TestMiddlewareUnaryServerInterceptor(t *testing.T) {
+	var seen []nativeRequest
+	m := func(next endpoint.Endpoint[nativeRequest, *pb.TestResponse]) endpoint.Endpoint[nativeRequest, *pb.TestResponse] {
+		return func(ctx context.Context, req nativeRequest) (*pb.TestResponse, error) {
+			seen = append(seen, req)
+			if req.A == "deny" {
+				return nil, errkind.New(errkind.PermissionDenied, "denied")
+			}
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return &pb.TestResponse{V: resp.V + "!"}, nil
+		}
+	}
+	cc := startBufconnServer(t,
+		&nativeBinding{test: grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse)},
+		grpc.UnaryInterceptor(grpctransport.MiddlewareUnaryServerInterceptor(m, nativeDecodeRequest)))
+	client := grpctransport.NewNativeClient(cc, "pb.Test", "Test", nativeEncodeRequest, nativeDecodeResponse).Endpoint()
+
+	resp, err := client(context.Background(), nativeRequest{A: "a", B: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a = 1!", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	_, err = client(context.Background(), nativeRequest{A: "deny"})
+	if want, have := codes.PermissionDenied, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	_, err = client(context.Background(), nativeRequest{A: "a", B: -1})
+	if want, have := codes.InvalidArgument, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	want := []nativeRequest{{A: "a", B: 1}, {A: "deny"}, {A: "a", B: -1}}
+	if have := seen; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestMiddlewareUnaryServerInterceptorOtherType(t *testing.T) {
+	interceptor := grpctransport.MiddlewareUnaryServerInterceptor(
+		func(endpoint.Endpoint[nativeRequest, *pb.TestResponse]) endpoint.Endpoint[nativeRequest, *pb.TestResponse] {
+			t.Error("middleware called for another request type")
+			return nil
+		}, nativeDecodeRequest)
+	resp, err := interceptor(context.Background(), "other", &grpc.UnaryServerInfo{},
+		func(_ context.Context, req interface{}) (interface{}, error) { return req, nil })
+	if err != nil || resp != "other" {
+		t.Errorf("want %q, have %v (%v)", "other", resp, err)
+	}
+}
+
+func TestUnaryClientInterceptorMiddleware(t *testing.T) {
+	var (
+		calls []string
+		conn  *grpc.ClientConn
+	)
+	record := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if cc != conn {
+				t.Errorf("want %p, have %p", conn, cc)
+			}
+			calls = append(calls, name+" "+method+" "+req.(nativeRequest).A)
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			calls = append(calls, name+" "+reply.(*nativeResponse).V)
+			return err
+		}
+	}
+	deny := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if req.(nativeRequest).A == "deny" {
+			return errors.New("denied")
+		}
+		if req.(nativeRequest).A == "forbid" {
+			return status.Error(codes.PermissionDenied, "forbidden")
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	conn = startBufconnServer(t, &nativeBinding{
+		test: grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse),
+	})
+	client := grpctransport.UnaryClientInterceptorMiddleware[nativeRequest, nativeResponse](conn, "/pb.Test/Test",
+		record("outer"), deny, record("inner"))(
+		grpctransport.NewNativeClient(conn, "pb.Test", "Test", nativeEncodeRequest, nativeDecodeResponse).Endpoint())
+
+	resp, err := client(context.Background(), nativeRequest{A: "a", B: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a = 2", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	want := []string{"outer /pb.Test/Test a", "inner /pb.Test/Test a", "inner a = 2", "outer a = 2"}
+	if have := calls; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	calls = nil
+	if _, err := client(context.Background(), nativeRequest{A: "deny"}); err == nil || err.Error() != "denied" {
+		t.Errorf("want denied error, have %v", err)
+	}
+	want = []string{"outer /pb.Test/Test deny", "outer "}
+	if have := calls; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	if _, err := client(context.Background(), nativeRequest{A: "forbid"}); !errors.Is(err, errkind.PermissionDenied) {
+		t.Errorf("want errors.Is %q, have %v", errkind.PermissionDenied, err)
+	}
+}
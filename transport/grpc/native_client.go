@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// NativeClient is an alternative to Client which implements the call itself
+// instead of wrapping a Go kit client, so the domain request and response and
+// the gRPC messages are passed between the encoder and decoder with their
+// static types, without being converted to interface{}.
+//
+// It follows the same semantics as the Go kit client for the before and after
+// metadata functions and finalizers. The typed finalizers are executed after
+// the untyped ones.
+type NativeClient[Req any, PReq any, PResp any, Resp any] struct {
+	client     grpc.ClientConnInterface
+	method     string
+	enc        func(context.Context, Req) (PReq, error)
+	dec        func(context.Context, PResp) (Resp, error)
+	newReply   func() PResp
+	options    clientOptions
+	finalizers []ClientFinalizerTypedFunc[Req, Resp]
+}
+
+// NewNativeClient constructs a usable NativeClient for a single remote method.
+// PResp must be a pointer to the message of the RPC response type, which is not
+// required to be a protobuf message, so other codecs can be used.
+func NewNativeClient[Req any, PReq any, PResp any, Resp any](
+	cc grpc.ClientConnInterface,
+	serviceName string,
+	method string,
+	enc func(context.Context, Req) (PReq, error),
+	dec func(context.Context, PResp) (Resp, error),
+	options ...ClientOption,
+) *NativeClient[Req, PReq, PResp, Resp] {
+	return &NativeClient[Req, PReq, PResp, Resp]{
+		client:   cc,
+		method:   fmt.Sprintf("/%s/%s", serviceName, method),
+		enc:      enc,
+		dec:      dec,
+		newReply: newMessageFunc[PResp]("NewNativeClient"),
+		options:  newClientOptions(options),
+	}
+}
+
+// Finalizer adds functions which are executed at the end of every call, like
+// ClientFinalizer, and also receive the request, the decoded response and the
+// error of the call. They are executed after the ClientFinalizer functions,
+// and must be set before the endpoint is created.
+func (c *NativeClient[Req, PReq, PResp, Resp]) Finalizer(
+	f ...ClientFinalizerTypedFunc[Req, Resp]) *NativeClient[Req, PReq, PResp, Resp] {
+	c.finalizers = append(c.finalizers, f...)
+	return c
+}
+
+// Endpoint returns a usable endpoint that will invoke the gRPC specified by the
+// client. Errors with a gRPC status have the errkind kind of their code, so
+// errors.Is(err, errkind.NotFound) works for errors returned by servers, and
+// their google.rpc error details are available with errkind.DetailOf.
+func (c NativeClient[Req, PReq, PResp, Resp]) Endpoint() endpoint.Endpoint[Req, Resp] {
+	return func(ctx context.Context, request Req) (response Resp, err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if len(c.options.finalizer) > 0 || len(c.finalizers) > 0 {
+			defer func() {
+				for _, f := range c.options.finalizer {
+					f(ctx, err)
+				}
+				for _, f := range c.finalizers {
+					f(ctx, request, response, err)
+				}
+			}()
+		}
+
+		ctx = context.WithValue(ctx, gokitgrpctransport.ContextKeyRequestMethod, c.method)
+
+		req, err := c.enc(ctx, request)
+		if err != nil {
+			return response, err
+		}
+
+		md := &metadata.MD{}
+		for _, f := range c.options.before {
+			ctx = f(ctx, md)
+		}
+		ctx = metadata.NewOutgoingContext(ctx, *md)
+
+		var header, trailer metadata.MD
+		grpcReply := c.newReply()
+		if err = c.client.Invoke(
+			ctx, c.method, req, grpcReply, grpc.Header(&header),
+			grpc.Trailer(&trailer),
+		); err != nil {
+			return response, decodeErrorKind(err)
+		}
+
+		for _, f := range c.options.after {
+			ctx = f(ctx, header, trailer)
+		}
+
+		return c.dec(ctx, grpcReply)
+	}
+}
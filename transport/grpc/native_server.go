@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// NativeServer is an alternative to Server which implements the request
+// handling itself instead of wrapping a Go kit server, so the gRPC messages and
+// the domain request and response are passed between the decoder, endpoint and
+// encoder with their static types, without being converted to interface{}.
+//
+// It follows the same semantics as the Go kit server for the before and after
+// metadata functions, error handler and finalizers. Returned errors are
+// converted by the error encoder after the finalizers run, so like with Server,
+// the finalizers receive the original error. The typed hooks are executed after
+// the untyped ones.
+type NativeServer[PReq any, Req any, Resp any, PResp any] struct {
+	e       endpoint.Endpoint[Req, Resp]
+	dec     func(context.Context, PReq) (Req, error)
+	enc     func(context.Context, Resp) (PResp, error)
+	options serverOptions
+	hooks   serverHooks[Req, Resp]
+}
+
+// NewNativeServer constructs a new native server, which wraps the provided
+// endpoint. Consumers should write bindings that call ServeGRPC from the
+// concrete gRPC methods of their compiled protobuf definitions. The messages
+// are not required to be protobuf messages, so other codecs can be used.
+func NewNativeServer[PReq any, Req any, Resp any, PResp any](
+	e endpoint.Endpoint[Req, Resp],
+	dec func(context.Context, PReq) (Req, error),
+	enc func(context.Context, Resp) (PResp, error),
+	options ...ServerOption,
+) *NativeServer[PReq, Req, Resp, PResp] {
+	return &NativeServer[PReq, Req, Resp, PResp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		options: newServerOptions(options),
+	}
+}
+
+// After adds functions which are executed on the response metadata after the
+// endpoint is invoked, like ServerAfter, and also receive the endpoint
+// response. The typed hooks must be set before the server handles requests.
+func (s *NativeServer[PReq, Req, Resp, PResp]) After(
+	after ...ServerAfterFunc[Resp]) *NativeServer[PReq, Req, Resp, PResp] {
+	s.hooks.after = append(s.hooks.after, after...)
+	return s
+}
+
+// ErrorHandler sets a handler for non-terminal errors, like ServerErrorHandler,
+// which also receives the decoded request.
+func (s *NativeServer[PReq, Req, Resp, PResp]) ErrorHandler(
+	h ServerErrorHandlerFunc[Req]) *NativeServer[PReq, Req, Resp, PResp] {
+	s.hooks.errorHandler = h
+	return s
+}
+
+// Finalizer adds functions which are executed at the end of every gRPC
+// request, like ServerFinalizer, and also receive the decoded request, the
+// endpoint response and the error of the request.
+func (s *NativeServer[PReq, Req, Resp, PResp]) Finalizer(
+	f ...ServerFinalizerTypedFunc[Req, Resp]) *NativeServer[PReq, Req, Resp, PResp] {
+	s.hooks.finalizer = append(s.hooks.finalizer, f...)
+	return s
+}
+
+// ServeGRPC handles the gRPC request message, returning the response message.
+func (s NativeServer[PReq, Req, Resp, PResp]) ServeGRPC(ctx context.Context, req PReq) (retctx context.Context,
+	resp PResp, err error) {
+	// Retrieve gRPC metadata.
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+
+	var (
+		request  Req
+		response Resp
+	)
+
+	defer func() {
+		if err != nil {
+			err = s.options.errorEncoder(ctx, err)
+		}
+	}()
+
+	if len(s.options.finalizer) > 0 || len(s.hooks.finalizer) > 0 {
+		defer func() {
+			for _, f := range s.options.finalizer {
+				f(ctx, err)
+			}
+			s.hooks.finalize(ctx, request, response, err)
+		}()
+	}
+
+	for _, f := range s.options.before {
+		ctx = f(ctx, md)
+	}
+
+	request, err = s.dec(ctx, req)
+	if err != nil {
+		s.handleError(ctx, request, err)
+		return ctx, resp, err
+	}
+
+	response, err = s.e(ctx, request)
+	if err != nil {
+		s.handleError(ctx, request, err)
+		return ctx, resp, err
+	}
+
+	var mdHeader, mdTrailer metadata.MD
+	for _, f := range s.options.after {
+		ctx = f(ctx, &mdHeader, &mdTrailer)
+	}
+	ctx = s.hooks.runAfter(ctx, &mdHeader, &mdTrailer, response)
+
+	grpcResp, err := s.enc(ctx, response)
+	if err != nil {
+		s.handleError(ctx, request, err)
+		return ctx, resp, err
+	}
+
+	if len(mdHeader) > 0 {
+		if err = grpc.SendHeader(ctx, mdHeader); err != nil {
+			s.handleError(ctx, request, err)
+			return ctx, resp, err
+		}
+	}
+
+	if len(mdTrailer) > 0 {
+		if err = grpc.SetTrailer(ctx, mdTrailer); err != nil {
+			s.handleError(ctx, request, err)
+			return ctx, resp, err
+		}
+	}
+
+	return ctx, grpcResp, nil
+}
+
+func (s NativeServer[PReq, Req, Resp, PResp]) handleError(ctx context.Context, request Req, err error) {
+	s.options.handleError(ctx, err)
+	s.hooks.handleError(ctx, request, err)
+}
@@ -0,0 +1,278 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+type nativeRequest struct {
+	A string
+	B int64
+}
+
+type nativeResponse struct {
+	V string
+}
+
+func nativeEndpoint(_ context.Context, req nativeRequest) (nativeResponse, error) {
+	if req.B < 0 {
+		return nativeResponse{}, status.Error(codes.InvalidArgument, "negative")
+	}
+	return nativeResponse{V: fmt.Sprintf("%s = %d", req.A, req.B)}, nil
+}
+
+func nativeDecodeRequest(_ context.Context, req *pb.TestRequest) (nativeRequest, error) {
+	return nativeRequest{A: req.A, B: req.B}, nil
+}
+
+func nativeEncodeResponse(_ context.Context, resp nativeResponse) (*pb.TestResponse, error) {
+	return &pb.TestResponse{V: resp.V}, nil
+}
+
+func nativeEncodeRequest(_ context.Context, req nativeRequest) (*pb.TestRequest, error) {
+	return &pb.TestRequest{A: req.A, B: req.B}, nil
+}
+
+func nativeDecodeResponse(_ context.Context, resp *pb.TestResponse) (nativeResponse, error) {
+	return nativeResponse{V: resp.V}, nil
+}
+
+type nativeBinding struct {
+	pb.UnimplementedTestServer
+	test *grpctransport.NativeServer[*pb.TestRequest, nativeRequest, nativeResponse, *pb.TestResponse]
+}
+
+func (b *nativeBinding) Test(ctx context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
+	_, resp, err := b.test.ServeGRPC(ctx, req)
+	return resp, err
+}
+
+type wrapperBinding struct {
+	pb.UnimplementedTestServer
+	test func(context.Context, *pb.TestRequest) (*pb.TestResponse, error)
+}
+
+func (b *wrapperBinding) Test(ctx context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
+	return b.test(ctx, req)
+}
+
+// startBufconnServer starts a gRPC server listening on an in-memory
+// connection, and returns a client connection to it.
+func startBufconnServer(t testing.TB, srv pb.TestServer, options ...grpc.ServerOption) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(options...)
+	pb.RegisterTestServer(server, srv)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("unable to Dial: %+v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func TestNativeServerClient(t *testing.T) {
+	var (
+		serverFinalizerErr error
+		serverHandledErr   error
+		clientFinalizerErr error
+		trailerValue       string
+	)
+	cc := startBufconnServer(t, &nativeBinding{
+		test: grpctransport.NewNativeServer(
+			nativeEndpoint,
+			nativeDecodeRequest,
+			nativeEncodeResponse,
+			grpctransport.ServerBefore(func(ctx context.Context, md metadata.MD) context.Context {
+				if v := md.Get("x-request-id"); len(v) > 0 {
+					ctx = context.WithValue(ctx, nativeRequestIDKey{}, v[0])
+				}
+				return ctx
+			}),
+			grpctransport.ServerAfter(func(ctx context.Context, _ *metadata.MD, trailer *metadata.MD) context.Context {
+				id, _ := ctx.Value(nativeRequestIDKey{}).(string)
+				*trailer = metadata.Join(*trailer, metadata.Pairs("x-request-id-consumed", id))
+				return ctx
+			}),
+			grpctransport.ServerErrorHandler(errorHandlerFunc(func(_ context.Context, err error) {
+				serverHandledErr = err
+			})),
+			grpctransport.ServerFinalizer(func(_ context.Context, err error) {
+				serverFinalizerErr = err
+			}),
+		),
+	})
+
+	client := grpctransport.NewNativeClient(
+		cc,
+		"pb.Test",
+		"Test",
+		nativeEncodeRequest,
+		nativeDecodeResponse,
+		grpctransport.ClientBefore(func(ctx context.Context, md *metadata.MD) context.Context {
+			md.Set("x-request-id", "req-1")
+			return ctx
+		}),
+		grpctransport.ClientAfter(func(ctx context.Context, _ metadata.MD, trailer metadata.MD) context.Context {
+			if v := trailer.Get("x-request-id-consumed"); len(v) > 0 {
+				trailerValue = v[0]
+			}
+			return ctx
+		}),
+		grpctransport.ClientFinalizer(func(_ context.Context, err error) {
+			clientFinalizerErr = err
+		}),
+	)
+
+	resp, err := client.Endpoint()(context.Background(), nativeRequest{A: "answer", B: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "answer = 42", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "req-1", trailerValue; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if serverFinalizerErr != nil || clientFinalizerErr != nil {
+		t.Errorf("want nil errors, have %v, %v", serverFinalizerErr, clientFinalizerErr)
+	}
+
+	_, err = client.Endpoint()(context.Background(), nativeRequest{A: "answer", B: -1})
+	if want, have := codes.InvalidArgument, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := codes.InvalidArgument, status.Code(serverHandledErr); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := codes.InvalidArgument, status.Code(serverFinalizerErr); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := codes.InvalidArgument, status.Code(clientFinalizerErr); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestNativeServerDecodeError(t *testing.T) {
+	server := grpctransport.NewNativeServer(
+		nativeEndpoint,
+		func(context.Context, *pb.TestRequest) (nativeRequest, error) {
+			return nativeRequest{}, errors.New("dang")
+		},
+		nativeEncodeResponse,
+	)
+	_, resp, err := server.ServeGRPC(context.Background(), &pb.TestRequest{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if resp != nil {
+		t.Errorf("want nil response, have %v", resp)
+	}
+}
+
+type nativeRequestIDKey struct{}
+
+type errorHandlerFunc func(ctx context.Context, err error)
+
+func (f errorHandlerFunc) Handle(ctx context.Context, err error) {
+	f(ctx, err)
+}
+
+func BenchmarkServeGRPC(b *testing.B) {
+	server := grpctransport.NewServer(
+		nativeEndpoint,
+		func(ctx context.Context, req interface{}) (nativeRequest, error) {
+			return nativeDecodeRequest(ctx, req.(*pb.TestRequest))
+		},
+		func(ctx context.Context, resp nativeResponse) (interface{}, error) {
+			return nativeEncodeResponse(ctx, resp)
+		},
+		gokitgrpctransport.ServerFinalizer(func(context.Context, error) {}),
+	)
+	req := &pb.TestRequest{A: "a", B: 1}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := server.ServeGRPC(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNativeServeGRPC(b *testing.B) {
+	server := grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse,
+		grpctransport.ServerFinalizer(func(context.Context, error) {}))
+	req := &pb.TestRequest{A: "a", B: 1}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := server.ServeGRPC(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkCall(b *testing.B, e func(context.Context, nativeRequest) (nativeResponse, error)) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := e(ctx, nativeRequest{A: "a", B: 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBufconn(b *testing.B) {
+	cc := startBufconnServer(b, &wrapperBinding{
+		test: grpctransport.ServerFunc[*pb.TestRequest, *pb.TestResponse](grpctransport.NewServer(
+			nativeEndpoint,
+			func(ctx context.Context, req interface{}) (nativeRequest, error) {
+				return nativeDecodeRequest(ctx, req.(*pb.TestRequest))
+			},
+			func(ctx context.Context, resp nativeResponse) (interface{}, error) {
+				return nativeEncodeResponse(ctx, resp)
+			},
+		)),
+	})
+	client := grpctransport.NewClient(
+		cc,
+		"pb.Test",
+		"Test",
+		func(ctx context.Context, req nativeRequest) (interface{}, error) {
+			return nativeEncodeRequest(ctx, req)
+		},
+		func(ctx context.Context, resp interface{}) (nativeResponse, error) {
+			return nativeDecodeResponse(ctx, resp.(*pb.TestResponse))
+		},
+		&pb.TestResponse{},
+	)
+	benchmarkCall(b, client.Endpoint())
+}
+
+func BenchmarkNativeBufconn(b *testing.B) {
+	cc := startBufconnServer(b, &nativeBinding{
+		test: grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse),
+	})
+	client := grpctransport.NewNativeClient(cc, "pb.Test", "Test", nativeEncodeRequest, nativeDecodeResponse)
+	benchmarkCall(b, client.Endpoint())
+}
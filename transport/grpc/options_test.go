@@ -0,0 +1,237 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+)
+
+func decodeNativeRequestStd(ctx context.Context, req interface{}) (nativeRequest, error) {
+	return nativeDecodeRequest(ctx, req.(*pb.TestRequest))
+}
+
+func encodeNativeResponseStd(ctx context.Context, resp nativeResponse) (interface{}, error) {
+	return nativeEncodeResponse(ctx, resp)
+}
+
+// typedHooks records the calls of the hooks in calls.
+type typedHooks struct {
+	calls []string
+}
+
+func (h *typedHooks) finalizer(_ context.Context, err error) {
+	h.calls = append(h.calls, fmt.Sprintf("finalizer %v", err != nil))
+}
+
+func (h *typedHooks) after(ctx context.Context, _ *metadata.MD, _ *metadata.MD,
+	response nativeResponse) context.Context {
+	h.calls = append(h.calls, "after "+response.V)
+	return ctx
+}
+
+func (h *typedHooks) errorHandler(_ context.Context, request nativeRequest, _ error) {
+	h.calls = append(h.calls, fmt.Sprintf("error %s %d", request.A, request.B))
+}
+
+func (h *typedHooks) typedFinalizer(_ context.Context, request nativeRequest, response nativeResponse, err error) {
+	h.calls = append(h.calls, fmt.Sprintf("typed finalizer %s %d %q %v", request.A, request.B, response.V,
+		err != nil))
+}
+
+func TestServerTypedHooks(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		serve func(h *typedHooks, req *pb.TestRequest) error
+	}{
+		{
+			name: "server",
+			serve: func(h *typedHooks, req *pb.TestRequest) error {
+				server := grpctransport.NewServer(nativeEndpoint, decodeNativeRequestStd, encodeNativeResponseStd,
+					gokitgrpctransport.ServerFinalizer(h.finalizer)).
+					After(h.after).
+					ErrorHandler(h.errorHandler).
+					Finalizer(h.typedFinalizer)
+				_, _, err := server.ServeGRPC(context.Background(), req)
+				return err
+			},
+		},
+		{
+			name: "native server",
+			serve: func(h *typedHooks, req *pb.TestRequest) error {
+				server := grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse,
+					grpctransport.ServerFinalizer(h.finalizer)).
+					After(h.after).
+					ErrorHandler(h.errorHandler).
+					Finalizer(h.typedFinalizer)
+				_, _, err := server.ServeGRPC(context.Background(), req)
+				return err
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &typedHooks{}
+			if err := tc.serve(h, &pb.TestRequest{A: "a", B: 1}); err != nil {
+				t.Fatal(err)
+			}
+			want := []string{"after a = 1", "finalizer false", `typed finalizer a 1 "a = 1" false`}
+			if have := h.calls; !reflect.DeepEqual(want, have) {
+				t.Errorf("want %q, have %q", want, have)
+			}
+
+			h = &typedHooks{}
+			err := tc.serve(h, &pb.TestRequest{A: "a", B: -1})
+			if want, have := codes.InvalidArgument, status.Code(err); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+			want = []string{"error a -1", "finalizer true", `typed finalizer a -1 "" true`}
+			if have := h.calls; !reflect.DeepEqual(want, have) {
+				t.Errorf("want %q, have %q", want, have)
+			}
+		})
+	}
+}
+
+func TestServerTypedAndGokitErrorHandler(t *testing.T) {
+	h := &typedHooks{}
+	server := grpctransport.NewServer(nativeEndpoint, decodeNativeRequestStd, encodeNativeResponseStd,
+		gokitgrpctransport.ServerErrorHandler(errorHandlerFunc(func(_ context.Context, err error) {
+			h.calls = append(h.calls, "gokit error")
+		}))).
+		ErrorHandler(h.errorHandler)
+	if _, _, err := server.ServeGRPC(context.Background(), &pb.TestRequest{A: "a", B: -1}); err == nil {
+		t.Fatal("want error, have nil")
+	}
+	want := []string{"error a -1", "gokit error"}
+	if have := h.calls; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestServerErrorEncoder(t *testing.T) {
+	server := grpctransport.NewServer(
+		func(context.Context, nativeRequest) (nativeResponse, error) {
+			return nativeResponse{}, errors.New("dang")
+		},
+		decodeNativeRequestStd,
+		encodeNativeResponseStd,
+	).ErrorEncoder(func(_ context.Context, err error) error {
+		return status.Error(codes.Aborted, err.Error())
+	})
+	_, _, err := server.ServeGRPC(context.Background(), &pb.TestRequest{})
+	if want, have := codes.Aborted, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestServerFinalizerError(t *testing.T) {
+	dang := errors.New("dang")
+	for _, tc := range []struct {
+		name  string
+		serve func(finalizer func(context.Context, error)) error
+	}{
+		{
+			name: "server",
+			serve: func(finalizer func(context.Context, error)) error {
+				server := grpctransport.NewServer(
+					func(context.Context, nativeRequest) (nativeResponse, error) { return nativeResponse{}, dang },
+					decodeNativeRequestStd, encodeNativeResponseStd,
+					gokitgrpctransport.ServerFinalizer(finalizer))
+				_, _, err := server.ServeGRPC(context.Background(), &pb.TestRequest{})
+				return err
+			},
+		},
+		{
+			name: "native server",
+			serve: func(finalizer func(context.Context, error)) error {
+				server := grpctransport.NewNativeServer(
+					func(context.Context, nativeRequest) (nativeResponse, error) { return nativeResponse{}, dang },
+					nativeDecodeRequest, nativeEncodeResponse,
+					grpctransport.ServerFinalizer(finalizer))
+				_, _, err := server.ServeGRPC(context.Background(), &pb.TestRequest{})
+				return err
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var finalizerErr error
+			err := tc.serve(func(_ context.Context, err error) { finalizerErr = err })
+			if want, have := dang, finalizerErr; want != have {
+				t.Errorf("want %v, have %v", want, have)
+			}
+			if want, have := codes.Unknown, status.Code(err); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+		})
+	}
+}
+
+func TestClientStdCodecsAndTypedFinalizer(t *testing.T) {
+	cc := startBufconnServer(t, &nativeBinding{
+		test: grpctransport.NewNativeServer(nativeEndpoint, nativeDecodeRequest, nativeEncodeResponse),
+	})
+
+	var finalized []string
+	finalizer := func(_ context.Context, request nativeRequest, response nativeResponse, err error) {
+		finalized = append(finalized, fmt.Sprintf("%s %q %v", request.A, response.V, status.Code(err)))
+	}
+	encodeStd := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nativeEncodeRequest(ctx, req.(nativeRequest))
+	}
+	decodeStd := func(ctx context.Context, resp interface{}) (interface{}, error) {
+		return nativeDecodeResponse(ctx, resp.(*pb.TestResponse))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		client *grpctransport.Client[nativeRequest, nativeResponse]
+	}{
+		{
+			name: "std enc",
+			client: grpctransport.NewClientStdEnc[nativeRequest, nativeResponse](cc, "pb.Test", "Test", encodeStd,
+				grpctransport.ProtoDecodeResponseFuncAdapter(nativeDecodeResponse), &pb.TestResponse{}).
+				Finalizer(finalizer),
+		},
+		{
+			name: "std dec",
+			client: grpctransport.NewClientStdDec[nativeRequest, nativeResponse](cc, "pb.Test", "Test",
+				grpctransport.ProtoEncodeRequestFuncAdapter(nativeEncodeRequest), decodeStd, &pb.TestResponse{}).
+				Finalizer(finalizer),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			finalized = nil
+			resp, err := tc.client.Endpoint()(context.Background(), nativeRequest{A: "a", B: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, have := "a = 1", resp.V; want != have {
+				t.Errorf("want %q, have %q", want, have)
+			}
+			_, _ = tc.client.Endpoint()(context.Background(), nativeRequest{A: "b", B: -1})
+			want := []string{`a "a = 1" OK`, `b "" InvalidArgument`}
+			if have := finalized; !reflect.DeepEqual(want, have) {
+				t.Errorf("want %q, have %q", want, have)
+			}
+		})
+	}
+
+	finalized = nil
+	native := grpctransport.NewNativeClient(cc, "pb.Test", "Test", nativeEncodeRequest, nativeDecodeResponse).
+		Finalizer(finalizer)
+	if _, err := native.Endpoint()(context.Background(), nativeRequest{A: "c", B: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{`c "c = 2" OK`}, finalized; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
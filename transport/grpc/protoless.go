@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/endpoint/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// JSONCodec is a gRPC codec which marshals messages as JSON, with the "json"
+// content-subtype, for services without protobuf definitions. gRPC selects the
+// codec of a request by its content-subtype, so it must be registered on both
+// sides, usually in an init function:
+//
+//	encoding.RegisterCodec(grpctransport.JSONCodec)
+var JSONCodec encoding.Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+// ProtolessService is a gRPC service without protobuf definitions, whose
+// methods are typed endpoints. The request and response types are marshaled
+// by the registered codec of the content-subtype of each call, like JSONCodec,
+// so the service can only be called by clients which set one, like the ones
+// returned by NewProtolessClient.
+type ProtolessService struct {
+	name    string
+	methods []grpc.MethodDesc
+}
+
+// NewProtolessService constructs an empty service with the full service name,
+// like "pkg.Service".
+func NewProtolessService(name string) *ProtolessService {
+	return &ProtolessService{name: name}
+}
+
+// NewProtolessServiceFromRegistry constructs a service with a method for each
+// entry of the registry, named as the entry. The options are passed to the
+// servers of every method.
+func NewProtolessServiceFromRegistry(name string, r *registry.Registry,
+	options ...ServerOption) *ProtolessService {
+	s := NewProtolessService(name)
+	for _, entry := range r.Entries() {
+		s.HandleEntry(entry, options...)
+	}
+	return s
+}
+
+// HandleProtoless adds a method to the service which serves the endpoint.
+// Requests are decoded into a *Req, and the response is encoded as is.
+func HandleProtoless[Req any, Resp any](s *ProtolessService, method string, e endpoint.Endpoint[Req, Resp],
+	options ...ServerOption) {
+	srv := NewNativeServer(e,
+		func(_ context.Context, req *Req) (Req, error) { return *req, nil },
+		func(_ context.Context, resp Resp) (Resp, error) { return resp, nil },
+		options...)
+	s.handle(method, func() interface{} { return new(Req) },
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, resp, err := srv.ServeGRPC(ctx, req.(*Req))
+			return resp, err
+		})
+}
+
+// HandleEntry adds a method to the service, named as the entry, which serves
+// the endpoint of a registry entry. Requests are decoded into a new value of
+// the entry request type.
+func (s *ProtolessService) HandleEntry(entry *registry.Entry, options ...ServerOption) {
+	srv := NewNativeServer(endpoint.Adapter[interface{}, interface{}](entry.Endpoint),
+		func(_ context.Context, req interface{}) (interface{}, error) {
+			return reflect.ValueOf(req).Elem().Interface(), nil
+		},
+		func(_ context.Context, resp interface{}) (interface{}, error) { return resp, nil },
+		options...)
+	s.handle(entry.Name, func() interface{} { return reflect.New(entry.Request).Interface() },
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, resp, err := srv.ServeGRPC(ctx, req)
+			return resp, err
+		})
+}
+
+func (s *ProtolessService) handle(method string, newRequest func() interface{}, h grpc.UnaryHandler) {
+	fullMethod := fmt.Sprintf("/%s/%s", s.name, method)
+	s.methods = append(s.methods, grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newRequest()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return h(ctx, req)
+			}
+			return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}, h)
+		},
+	})
+}
+
+// ServiceDesc returns the descriptor of the service. Interceptors of the gRPC
+// server receive pointers to the request types.
+func (s *ProtolessService) ServiceDesc() *grpc.ServiceDesc {
+	methods := make([]grpc.MethodDesc, len(s.methods))
+	copy(methods, s.methods)
+	return &grpc.ServiceDesc{
+		ServiceName: s.name,
+		HandlerType: (*interface{})(nil),
+		Methods:     methods,
+	}
+}
+
+// Register registers the service on the gRPC server.
+func (s *ProtolessService) Register(r grpc.ServiceRegistrar) {
+	r.RegisterService(s.ServiceDesc(), s)
+}
+
+// NewProtolessClient constructs a client for a method of a ProtolessService.
+// The request and response are marshaled by the registered codec of the
+// content-subtype, like "json" for JSONCodec.
+func NewProtolessClient[Req any, Resp any](
+	cc grpc.ClientConnInterface,
+	serviceName string,
+	method string,
+	contentSubtype string,
+	options ...ClientOption,
+) *NativeClient[Req, Req, *Resp, Resp] {
+	return NewNativeClient(contentSubtypeConn{ClientConnInterface: cc, contentSubtype: contentSubtype},
+		serviceName, method,
+		func(_ context.Context, request Req) (Req, error) { return request, nil },
+		func(_ context.Context, resp *Resp) (Resp, error) { return *resp, nil },
+		options...)
+}
+
+// contentSubtypeConn sets the content-subtype of the unary calls made on the
+// connection.
+type contentSubtypeConn struct {
+	grpc.ClientConnInterface
+	contentSubtype string
+}
+
+func (c contentSubtypeConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{},
+	opts ...grpc.CallOption) error {
+	opts = append(opts, grpc.CallContentSubtype(c.contentSubtype))
+	return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
@@ -0,0 +1,153 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/RangelReale/go-kit-typed/endpoint/registry"
+	"github.com/RangelReale/go-kit-typed/errkind"
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+)
+
+func init() {
+	encoding.RegisterCodec(grpctransport.JSONCodec)
+}
+
+type sumRequest struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type sumResponse struct {
+	V int `json:"v"`
+}
+
+func sumEndpoint(_ context.Context, req sumRequest) (sumResponse, error) {
+	if req.A < 0 || req.B < 0 {
+		return sumResponse{}, errkind.New(errkind.InvalidArgument, "negative")
+	}
+	return sumResponse{V: req.A + req.B}, nil
+}
+
+func concatEndpoint(_ context.Context, req []string) (string, error) {
+	var ret string
+	for _, s := range req {
+		ret += s
+	}
+	return ret, nil
+}
+
+// serveProtoless starts the server on an in-memory listener and returns a
+// connection to it.
+func serveProtoless(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("unable to Dial: %+v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func TestProtolessService(t *testing.T) {
+	var intercepted []string
+	svc := grpctransport.NewProtolessService("test.Math")
+	grpctransport.HandleProtoless(svc, "Sum", sumEndpoint)
+	grpctransport.HandleProtoless(svc, "Concat", concatEndpoint)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		intercepted = append(intercepted, info.FullMethod)
+		return handler(ctx, req)
+	}))
+	svc.Register(server)
+	cc := serveProtoless(t, server)
+
+	sum := grpctransport.NewProtolessClient[sumRequest, sumResponse](cc, "test.Math", "Sum", "json").Endpoint()
+	resp, err := sum(context.Background(), sumRequest{A: 1, B: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	_, err = sum(context.Background(), sumRequest{A: -1})
+	if want, have := errkind.InvalidArgument, errkind.KindOf(err); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	concat := grpctransport.NewProtolessClient[[]string, string](cc, "test.Math", "Concat", "json").Endpoint()
+	s, err := concat(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "abc", s; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	want := []string{"/test.Math/Sum", "/test.Math/Sum", "/test.Math/Concat"}
+	if len(want) != len(intercepted) {
+		t.Fatalf("want %v, have %v", want, intercepted)
+	}
+	for i := range want {
+		if want[i] != intercepted[i] {
+			t.Errorf("want %v, have %v", want, intercepted)
+		}
+	}
+
+	desc := svc.ServiceDesc()
+	if want, have := 2, len(desc.Methods); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+func TestProtolessServiceFromRegistry(t *testing.T) {
+	r := registry.New()
+	registry.MustRegister(r, "Sum", sumEndpoint)
+	registry.MustRegister(r, "Concat", concatEndpoint)
+
+	var finalized []error
+	svc := grpctransport.NewProtolessServiceFromRegistry("test.Math", r,
+		grpctransport.ServerFinalizer(func(_ context.Context, err error) {
+			finalized = append(finalized, err)
+		}))
+	server := grpc.NewServer()
+	svc.Register(server)
+	cc := serveProtoless(t, server)
+
+	sum := grpctransport.NewProtolessClient[sumRequest, sumResponse](cc, "test.Math", "Sum", "json").Endpoint()
+	resp, err := sum(context.Background(), sumRequest{A: 2, B: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 7, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := []error{nil}, finalized; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	entry, _ := r.Lookup("Sum")
+	if want, have := uint64(1), entry.Stats().Calls; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	unknown := grpctransport.NewProtolessClient[sumRequest, sumResponse](cc, "test.Math", "Unknown", "json").Endpoint()
+	_, err = unknown(context.Background(), sumRequest{})
+	if !errors.Is(err, errkind.Unimplemented) {
+		t.Errorf("want %v, have %v", errkind.Unimplemented, err)
+	}
+}
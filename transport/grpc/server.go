@@ -4,12 +4,22 @@ import (
 	"context"
 
 	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
 	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 // Server wraps an endpoint and implements grpc.Handler.
+//
+// Returned errors are converted by the error encoder after the finalizers run,
+// so the finalizers receive the original error. The typed after functions and
+// finalizers are executed after the untyped ones, and the typed error handler
+// receives the decoder, endpoint and encoder errors before the Go kit one.
 type Server[Req any, Resp any] struct {
-	server *gokitgrpctransport.Server
+	server       *gokitgrpctransport.Server
+	errorEncoder ErrorEncoder
+	hooks        serverHooks[Req, Resp]
 }
 
 // NewServer constructs a new server, which implements wraps the provided
@@ -23,14 +33,11 @@ func NewServer[Req any, Resp any](
 	enc EncodeResponseFunc[Resp],
 	options ...gokitgrpctransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokitgrpctransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
 }
 
 // NewServerStdDec constructs a new server, which implements wraps the provided
@@ -44,14 +51,11 @@ func NewServerStdDec[Req any, Resp any](
 	enc EncodeResponseFunc[Resp],
 	options ...gokitgrpctransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokitgrpctransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		dec,
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
 }
 
 // NewServerStdEnc constructs a new server, which implements wraps the provided
@@ -65,17 +69,144 @@ func NewServerStdEnc[Req any, Resp any](
 	enc gokitgrpctransport.EncodeResponseFunc,
 	options ...gokitgrpctransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokitgrpctransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		enc,
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
+}
+
+// NewProtoServer constructs a new server, which wraps the provided endpoint and
+// implements the Handler interface, using decoders and encoders typed by the
+// gRPC request and response messages, so a mismatched message type is a
+// compile error. It accepts the options of NativeServer, including the error
+// encoder.
+func NewProtoServer[PReq proto.Message, Req any, Resp any, PResp proto.Message](
+	e endpoint.Endpoint[Req, Resp],
+	dec ProtoDecodeRequestFunc[PReq, Req],
+	enc ProtoEncodeResponseFunc[Resp, PResp],
+	options ...ServerOption,
+) *Server[Req, Resp] {
+	sopt := newServerOptions(options)
+	return NewServer(e, ProtoDecodeRequestFuncAdapter(dec), ProtoEncodeResponseFuncAdapter(enc),
+		sopt.gokitOptions()...).ErrorEncoder(sopt.errorEncoder)
+}
+
+// ErrorEncoder sets the function used to convert errors to the error returned
+// to gRPC. By default, errors are converted with the DefaultErrorEncoder.
+func (s *Server[Req, Resp]) ErrorEncoder(ee ErrorEncoder) *Server[Req, Resp] {
+	s.errorEncoder = ee
+	return s
+}
+
+// After adds functions which are executed on the response metadata after the
+// endpoint is invoked, like ServerAfter, and also receive the endpoint
+// response. The typed hooks must be set before the server handles requests.
+func (s *Server[Req, Resp]) After(after ...ServerAfterFunc[Resp]) *Server[Req, Resp] {
+	s.hooks.after = append(s.hooks.after, after...)
+	return s
+}
+
+// ErrorHandler sets a handler for non-terminal errors, like ServerErrorHandler,
+// which also receives the decoded request.
+func (s *Server[Req, Resp]) ErrorHandler(h ServerErrorHandlerFunc[Req]) *Server[Req, Resp] {
+	s.hooks.errorHandler = h
+	return s
+}
+
+// Finalizer adds functions which are executed at the end of every gRPC
+// request, like ServerFinalizer, and also receive the decoded request, the
+// endpoint response and the error of the request.
+func (s *Server[Req, Resp]) Finalizer(f ...ServerFinalizerTypedFunc[Req, Resp]) *Server[Req, Resp] {
+	s.hooks.finalizer = append(s.hooks.finalizer, f...)
+	return s
 }
 
 // ServeGRPC implements the Handler interface.
 func (s Server[Req, Resp]) ServeGRPC(ctx context.Context, req interface{}) (retctx context.Context, resp interface{}, err error) {
-	return s.server.ServeGRPC(ctx, req)
+	if !s.hooks.empty() {
+		ctx = context.WithValue(ctx, serverStateContextKey{}, &serverState{})
+	}
+	retctx, resp, err = s.server.ServeGRPC(ctx, req)
+	if err != nil {
+		err = s.errorEncoder(retctx, err)
+	}
+	return retctx, resp, err
+}
+
+// newServer constructs the server and its Go kit server. The endpoint,
+// decoder and encoder are wrapped, and after functions and a finalizer added
+// after the passed options, to run the typed hooks set later, which read the
+// per-request state from the context.
+func newServer[Req any, Resp any](e gokitendpoint.Endpoint, dec gokitgrpctransport.DecodeRequestFunc,
+	enc gokitgrpctransport.EncodeResponseFunc, options []gokitgrpctransport.ServerOption) *Server[Req, Resp] {
+	s := &Server[Req, Resp]{
+		errorEncoder: DefaultErrorEncoder,
+	}
+	options = append(options,
+		gokitgrpctransport.ServerAfter(func(ctx context.Context, header *metadata.MD, trailer *metadata.MD) context.Context {
+			if state := serverStateFrom(ctx); state != nil {
+				response, _ := state.response.(Resp)
+				ctx = s.hooks.runAfter(ctx, header, trailer, response)
+			}
+			return ctx
+		}),
+		gokitgrpctransport.ServerFinalizer(func(ctx context.Context, err error) {
+			if state := serverStateFrom(ctx); state != nil {
+				request, _ := state.request.(Req)
+				response, _ := state.response.(Resp)
+				s.hooks.finalize(ctx, request, response, err)
+			}
+		}),
+	)
+	s.server = gokitgrpctransport.NewServer(
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			response, err := e(ctx, request)
+			if state := serverStateFrom(ctx); state != nil {
+				state.response = response
+				s.handleError(ctx, state, err)
+			}
+			return response, err
+		},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			request, err := dec(ctx, req)
+			if state := serverStateFrom(ctx); state != nil {
+				state.request = request
+				s.handleError(ctx, state, err)
+			}
+			return request, err
+		},
+		func(ctx context.Context, response interface{}) (interface{}, error) {
+			reply, err := enc(ctx, response)
+			if state := serverStateFrom(ctx); state != nil {
+				s.handleError(ctx, state, err)
+			}
+			return reply, err
+		},
+		options...)
+	return s
+}
+
+// handleError runs the typed error handler with the request recorded in the
+// state.
+func (s *Server[Req, Resp]) handleError(ctx context.Context, state *serverState, err error) {
+	if err != nil {
+		request, _ := state.request.(Req)
+		s.hooks.handleError(ctx, request, err)
+	}
+}
+
+// serverState is the per-request state used by the typed hooks.
+type serverState struct {
+	request  interface{}
+	response interface{}
+}
+
+type serverStateContextKey struct{}
+
+// serverStateFrom returns the state of the request, or nil when no typed hooks
+// are set.
+func serverStateFrom(ctx context.Context) *serverState {
+	state, _ := ctx.Value(serverStateContextKey{}).(*serverState)
+	return state
 }
@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"github.com/go-kit/kit/transport"
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOption sets an optional parameter for servers.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	before       []gokitgrpctransport.ServerRequestFunc
+	after        []gokitgrpctransport.ServerResponseFunc
+	errorEncoder ErrorEncoder
+	errorHandler transport.ErrorHandler
+	finalizer    []gokitgrpctransport.ServerFinalizerFunc
+}
+
+func newServerOptions(options []ServerOption) serverOptions {
+	sopt := serverOptions{
+		errorEncoder: DefaultErrorEncoder,
+	}
+	for _, opt := range options {
+		opt(&sopt)
+	}
+	return sopt
+}
+
+// gokitOptions returns the options for a Go kit server, without the typed
+// hooks.
+func (s serverOptions) gokitOptions() []gokitgrpctransport.ServerOption {
+	var ret []gokitgrpctransport.ServerOption
+	if len(s.before) > 0 {
+		ret = append(ret, gokitgrpctransport.ServerBefore(s.before...))
+	}
+	if len(s.after) > 0 {
+		ret = append(ret, gokitgrpctransport.ServerAfter(s.after...))
+	}
+	if s.errorHandler != nil {
+		ret = append(ret, gokitgrpctransport.ServerErrorHandler(s.errorHandler))
+	}
+	if len(s.finalizer) > 0 {
+		ret = append(ret, gokitgrpctransport.ServerFinalizer(s.finalizer...))
+	}
+	return ret
+}
+
+func (s serverOptions) handleError(ctx context.Context, err error) {
+	if s.errorHandler != nil {
+		s.errorHandler.Handle(ctx, err)
+	}
+}
+
+// serverHooks are the typed hooks of a unary server, set with its After,
+// ErrorHandler and Finalizer methods.
+type serverHooks[Req any, Resp any] struct {
+	after        []ServerAfterFunc[Resp]
+	errorHandler ServerErrorHandlerFunc[Req]
+	finalizer    []ServerFinalizerTypedFunc[Req, Resp]
+}
+
+func (h serverHooks[Req, Resp]) empty() bool {
+	return len(h.after) == 0 && h.errorHandler == nil && len(h.finalizer) == 0
+}
+
+// runAfter runs the typed after functions.
+func (h serverHooks[Req, Resp]) runAfter(ctx context.Context, header *metadata.MD, trailer *metadata.MD,
+	response Resp) context.Context {
+	for _, f := range h.after {
+		ctx = f(ctx, header, trailer, response)
+	}
+	return ctx
+}
+
+// handleError runs the typed error handler.
+func (h serverHooks[Req, Resp]) handleError(ctx context.Context, request Req, err error) {
+	if h.errorHandler != nil {
+		h.errorHandler(ctx, request, err)
+	}
+}
+
+// finalize runs the typed finalizers.
+func (h serverHooks[Req, Resp]) finalize(ctx context.Context, request Req, response Resp, err error) {
+	for _, f := range h.finalizer {
+		f(ctx, request, response, err)
+	}
+}
+
+// ServerBefore functions are executed on the gRPC request object before the
+// request is decoded.
+func ServerBefore(before ...gokitgrpctransport.ServerRequestFunc) ServerOption {
+	return func(s *serverOptions) { s.before = append(s.before, before...) }
+}
+
+// ServerAfter functions are executed on the gRPC response writer after the
+// endpoint is invoked, but before anything is written to the client.
+func ServerAfter(after ...gokitgrpctransport.ServerResponseFunc) ServerOption {
+	return func(s *serverOptions) { s.after = append(s.after, after...) }
+}
+
+// ServerErrorEncoder is used to convert errors to the error returned to gRPC,
+// which is usually created by the status package. By default, errors are
+// converted with the DefaultErrorEncoder.
+func ServerErrorEncoder(ee ErrorEncoder) ServerOption {
+	return func(s *serverOptions) { s.errorEncoder = ee }
+}
+
+// ServerErrorHandler is used to handle non-terminal errors. By default,
+// non-terminal errors are ignored.
+func ServerErrorHandler(errorHandler transport.ErrorHandler) ServerOption {
+	return func(s *serverOptions) { s.errorHandler = errorHandler }
+}
+
+// ServerFinalizer is executed at the end of every gRPC request.
+// By default, no finalizer is registered.
+func ServerFinalizer(f ...gokitgrpctransport.ServerFinalizerFunc) ServerOption {
+	return func(s *serverOptions) { s.finalizer = append(s.finalizer, f...) }
+}
+
+// ServerAfterFunc is a typed ServerAfter function, which receives the response
+// returned by the endpoint.
+type ServerAfterFunc[Resp any] func(ctx context.Context, header *metadata.MD, trailer *metadata.MD,
+	response Resp) context.Context
+
+// ServerErrorHandlerFunc is a typed error handler, which receives the decoded
+// request, or its zero value if the error happened while decoding.
+type ServerErrorHandlerFunc[Req any] func(ctx context.Context, request Req, err error)
+
+// ServerFinalizerTypedFunc is a typed ServerFinalizer function, which receives
+// the decoded request, the endpoint response and the error of the request, if
+// any. The request and response are zero values when the request failed
+// before they were available.
+type ServerFinalizerTypedFunc[Req any, Resp any] func(ctx context.Context, request Req, response Resp, err error)
+
+// ErrorEncoder converts an error returned by the endpoint, decoder or encoder
+// to the error returned to gRPC, which carries the status code sent to the
+// client.
+type ErrorEncoder func(ctx context.Context, err error) error
+
+// DefaultErrorEncoder keeps errors which have a gRPC status, and converts
+// other errors to a status with the code of their errkind kind, so context
+// cancellation and deadline errors have the Canceled and DeadlineExceeded
+// codes, and errors without a kind the Unknown code. The errkind details of
+// the error are sent as google.rpc error details.
+func DefaultErrorEncoder(_ context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return withErrorDetails(status.New(errkind.GRPCCode(errkind.KindOf(err)), err.Error()), err).Err()
+}
@@ -0,0 +1,366 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokitgrpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// streamClient holds the parts common to the streaming clients.
+type streamClient struct {
+	client  grpc.ClientConnInterface
+	method  string
+	desc    grpc.StreamDesc
+	options clientOptions
+}
+
+func newStreamClient(cc grpc.ClientConnInterface, serviceName, method string, desc grpc.StreamDesc,
+	options []ClientOption) streamClient {
+	desc.StreamName = method
+	return streamClient{
+		client:  cc,
+		method:  fmt.Sprintf("/%s/%s", serviceName, method),
+		desc:    desc,
+		options: newClientOptions(options),
+	}
+}
+
+// open runs the before functions and opens the gRPC stream.
+func (c streamClient) open(ctx context.Context) (context.Context, grpc.ClientStream, error) {
+	ctx = context.WithValue(ctx, gokitgrpctransport.ContextKeyRequestMethod, c.method)
+
+	md := &metadata.MD{}
+	for _, f := range c.options.before {
+		ctx = f(ctx, md)
+	}
+	ctx = metadata.NewOutgoingContext(ctx, *md)
+
+	stream, err := c.client.NewStream(ctx, &c.desc, c.method)
+	return ctx, stream, err
+}
+
+// after runs the after functions once the stream ended, when the trailer is
+// available.
+func (c streamClient) after(ctx context.Context, stream grpc.ClientStream) context.Context {
+	if len(c.options.after) == 0 {
+		return ctx
+	}
+	header, _ := stream.Header()
+	trailer := stream.Trailer()
+	for _, f := range c.options.after {
+		ctx = f(ctx, header, trailer)
+	}
+	return ctx
+}
+
+func (c streamClient) finalize(ctx context.Context, err error) {
+	for _, f := range c.options.finalizer {
+		f(ctx, err)
+	}
+}
+
+// ServerStreamClient wraps a server-streaming gRPC method and provides a
+// method that implements endpoint.ServerStreamEndpoint.
+//
+// The after functions run when the stream ends, as the trailer is only
+// available then, so their context is only seen by the finalizers. As with the
+// unary clients, errors with a gRPC status have the errkind kind of their code.
+type ServerStreamClient[Req any, PReq any, PResp any, Resp any] struct {
+	streamClient
+	enc      func(context.Context, Req) (PReq, error)
+	dec      func(context.Context, PResp) (Resp, error)
+	newReply func() PResp
+}
+
+// NewServerStreamClient constructs a usable ServerStreamClient for a single
+// remote method. PResp must be a pointer to the protobuf message of the RPC
+// response type.
+func NewServerStreamClient[Req any, PReq any, PResp any, Resp any](
+	cc grpc.ClientConnInterface,
+	serviceName string,
+	method string,
+	enc func(context.Context, Req) (PReq, error),
+	dec func(context.Context, PResp) (Resp, error),
+	options ...ClientOption,
+) *ServerStreamClient[Req, PReq, PResp, Resp] {
+	return &ServerStreamClient[Req, PReq, PResp, Resp]{
+		streamClient: newStreamClient(cc, serviceName, method, grpc.StreamDesc{ServerStreams: true}, options),
+		enc:          enc,
+		dec:          dec,
+		newReply:     newMessageFunc[PResp]("NewServerStreamClient"),
+	}
+}
+
+// Endpoint returns a usable endpoint that will invoke the gRPC specified by the
+// client, calling send for each received message. If send returns an error, the
+// stream is canceled and the error is returned.
+func (c ServerStreamClient[Req, PReq, PResp, Resp]) Endpoint() endpoint.ServerStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, request Req, send func(Resp) error) (err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if len(c.options.finalizer) > 0 {
+			defer func() { c.finalize(ctx, err) }()
+		}
+		defer func() { err = decodeErrorKind(err) }()
+
+		ctx, stream, err := c.open(ctx)
+		if err != nil {
+			return err
+		}
+
+		req, err := c.enc(ctx, request)
+		if err != nil {
+			return err
+		}
+		if err = stream.SendMsg(req); err != nil && err != io.EOF {
+			return err
+		}
+		if err = stream.CloseSend(); err != nil {
+			return err
+		}
+
+		for {
+			grpcReply := c.newReply()
+			if err = stream.RecvMsg(grpcReply); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return err
+			}
+			response, err := c.dec(ctx, grpcReply)
+			if err != nil {
+				return err
+			}
+			if err = send(response); err != nil {
+				return err
+			}
+		}
+
+		ctx = c.after(ctx, stream)
+		return nil
+	}
+}
+
+// ClientStreamClient wraps a client-streaming gRPC method and provides a
+// method that implements endpoint.ClientStreamEndpoint.
+//
+// The after functions run when the response is received, before it is
+// decoded.
+type ClientStreamClient[Req any, PReq any, PResp any, Resp any] struct {
+	streamClient
+	enc      func(context.Context, Req) (PReq, error)
+	dec      func(context.Context, PResp) (Resp, error)
+	newReply func() PResp
+}
+
+// NewClientStreamClient constructs a usable ClientStreamClient for a single
+// remote method. PResp must be a pointer to the protobuf message of the RPC
+// response type.
+func NewClientStreamClient[Req any, PReq any, PResp any, Resp any](
+	cc grpc.ClientConnInterface,
+	serviceName string,
+	method string,
+	enc func(context.Context, Req) (PReq, error),
+	dec func(context.Context, PResp) (Resp, error),
+	options ...ClientOption,
+) *ClientStreamClient[Req, PReq, PResp, Resp] {
+	return &ClientStreamClient[Req, PReq, PResp, Resp]{
+		streamClient: newStreamClient(cc, serviceName, method, grpc.StreamDesc{ClientStreams: true}, options),
+		enc:          enc,
+		dec:          dec,
+		newReply:     newMessageFunc[PResp]("NewClientStreamClient"),
+	}
+}
+
+// Endpoint returns a usable endpoint that will invoke the gRPC specified by the
+// client, sending each request returned by recv until it returns io.EOF. If
+// recv returns another error, the stream is canceled and the error is
+// returned.
+func (c ClientStreamClient[Req, PReq, PResp, Resp]) Endpoint() endpoint.ClientStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, recv func() (Req, error)) (response Resp, err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if len(c.options.finalizer) > 0 {
+			defer func() { c.finalize(ctx, err) }()
+		}
+		defer func() { err = decodeErrorKind(err) }()
+
+		ctx, stream, err := c.open(ctx)
+		if err != nil {
+			return response, err
+		}
+
+		for {
+			request, err := recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return response, err
+			}
+			req, err := c.enc(ctx, request)
+			if err != nil {
+				return response, err
+			}
+			if err = stream.SendMsg(req); err != nil {
+				if err == io.EOF {
+					// the server ended the stream, RecvMsg returns its status.
+					break
+				}
+				return response, err
+			}
+		}
+		if err = stream.CloseSend(); err != nil {
+			return response, err
+		}
+
+		grpcReply := c.newReply()
+		if err = stream.RecvMsg(grpcReply); err != nil {
+			return response, err
+		}
+
+		ctx = c.after(ctx, stream)
+
+		return c.dec(ctx, grpcReply)
+	}
+}
+
+// BidiStreamClient wraps a bidirectional streaming gRPC method and provides a
+// method that implements endpoint.BidiStreamEndpoint.
+//
+// The after functions run when the stream ends, as the trailer is only
+// available then, so their context is only seen by the finalizers.
+type BidiStreamClient[Req any, PReq any, PResp any, Resp any] struct {
+	streamClient
+	enc      func(context.Context, Req) (PReq, error)
+	dec      func(context.Context, PResp) (Resp, error)
+	newReply func() PResp
+}
+
+// NewBidiStreamClient constructs a usable BidiStreamClient for a single remote
+// method. PResp must be a pointer to the protobuf message of the RPC response
+// type.
+func NewBidiStreamClient[Req any, PReq any, PResp any, Resp any](
+	cc grpc.ClientConnInterface,
+	serviceName string,
+	method string,
+	enc func(context.Context, Req) (PReq, error),
+	dec func(context.Context, PResp) (Resp, error),
+	options ...ClientOption,
+) *BidiStreamClient[Req, PReq, PResp, Resp] {
+	return &BidiStreamClient[Req, PReq, PResp, Resp]{
+		streamClient: newStreamClient(cc, serviceName, method,
+			grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, options),
+		enc:      enc,
+		dec:      dec,
+		newReply: newMessageFunc[PResp]("NewBidiStreamClient"),
+	}
+}
+
+// Endpoint returns a usable endpoint that will invoke the gRPC specified by the
+// client. The requests returned by recv are sent from a separate goroutine,
+// until it returns io.EOF, while send is called for each received message.
+// The first error of either side cancels the stream and is returned.
+//
+// If the stream ends while recv is running, the endpoint returns without
+// waiting for it, its result is discarded and it is not called again.
+func (c BidiStreamClient[Req, PReq, PResp, Resp]) Endpoint() endpoint.BidiStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, recv func() (Req, error), send func(Resp) error) (err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if len(c.options.finalizer) > 0 {
+			defer func() { c.finalize(ctx, err) }()
+		}
+		defer func() { err = decodeErrorKind(err) }()
+
+		ctx, stream, err := c.open(ctx)
+		if err != nil {
+			return err
+		}
+
+		sendErr := make(chan error, 1)
+		go func() {
+			err := c.sendAll(ctx, stream, recv)
+			sendErr <- err
+			if err != nil {
+				cancel()
+			}
+		}()
+
+		for {
+			grpcReply := c.newReply()
+			if err = stream.RecvMsg(grpcReply); err != nil {
+				if err == io.EOF {
+					break
+				}
+				// a sending error cancels the stream, return it instead of
+				// the cancellation.
+				select {
+				case serr := <-sendErr:
+					if serr != nil {
+						return serr
+					}
+				default:
+				}
+				return err
+			}
+			response, err := c.dec(ctx, grpcReply)
+			if err != nil {
+				return err
+			}
+			if err = send(response); err != nil {
+				return err
+			}
+		}
+
+		// the server ended the stream, report an error of the sending side
+		// only if it already happened.
+		select {
+		case err = <-sendErr:
+			if err != nil {
+				return err
+			}
+		default:
+		}
+
+		ctx = c.after(ctx, stream)
+		return nil
+	}
+}
+
+// sendAll sends the requests returned by recv until it returns io.EOF.
+func (c BidiStreamClient[Req, PReq, PResp, Resp]) sendAll(ctx context.Context, stream grpc.ClientStream,
+	recv func() (Req, error)) error {
+	for {
+		request, err := recv()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == io.EOF {
+			return stream.CloseSend()
+		}
+		if err != nil {
+			return err
+		}
+		req, err := c.enc(ctx, request)
+		if err != nil {
+			return err
+		}
+		if err = stream.SendMsg(req); err != nil {
+			// on io.EOF the server ended the stream, RecvMsg returns its
+			// status.
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
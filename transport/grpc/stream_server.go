@@ -0,0 +1,254 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServerStreamServer wraps a server-streaming endpoint, decoding the request
+// message and encoding each response to a message sent on the gRPC stream.
+//
+// The before functions run before the request is decoded, and the after
+// functions before the first response is encoded, or when the endpoint
+// returns if it sends no response. The header set by the after functions is
+// sent with the first message, and the trailer when the stream ends. Errors
+// are converted by the error encoder after the finalizers run, so the
+// finalizers receive the original error.
+type ServerStreamServer[PReq any, Req any, Resp any, PResp any] struct {
+	e       endpoint.ServerStreamEndpoint[Req, Resp]
+	dec     func(context.Context, PReq) (Req, error)
+	enc     func(context.Context, Resp) (PResp, error)
+	options serverOptions
+}
+
+// NewServerStreamServer constructs a new server-streaming server, which wraps
+// the provided endpoint. Consumers should write bindings that call
+// ServeGRPCStream from the concrete gRPC methods of their compiled protobuf
+// definitions.
+func NewServerStreamServer[PReq any, Req any, Resp any, PResp any](
+	e endpoint.ServerStreamEndpoint[Req, Resp],
+	dec func(context.Context, PReq) (Req, error),
+	enc func(context.Context, Resp) (PResp, error),
+	options ...ServerOption,
+) *ServerStreamServer[PReq, Req, Resp, PResp] {
+	return &ServerStreamServer[PReq, Req, Resp, PResp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		options: newServerOptions(options),
+	}
+}
+
+// ServeGRPCStream handles the gRPC request message, sending the responses on
+// the stream.
+func (s ServerStreamServer[PReq, Req, Resp, PResp]) ServeGRPCStream(req PReq, stream grpc.ServerStream) error {
+	return serveGRPCStream(stream, s.options, func(ctx context.Context, ss *serverStream) error {
+		request, err := s.dec(ctx, req)
+		if err != nil {
+			return err
+		}
+		return s.e(ctx, request, sendFunc(ss, s.enc))
+	})
+}
+
+// ClientStreamServer wraps a client-streaming endpoint, decoding each message
+// received on the gRPC stream to a request and encoding the response.
+//
+// It follows the same semantics as ServerStreamServer for the before and after
+// metadata functions, errors and finalizers.
+type ClientStreamServer[PReq any, Req any, Resp any, PResp any] struct {
+	e       endpoint.ClientStreamEndpoint[Req, Resp]
+	dec     func(context.Context, PReq) (Req, error)
+	enc     func(context.Context, Resp) (PResp, error)
+	newReq  func() PReq
+	options serverOptions
+}
+
+// NewClientStreamServer constructs a new client-streaming server, which wraps
+// the provided endpoint. PReq must be a pointer to the protobuf message of the
+// RPC request type.
+func NewClientStreamServer[PReq any, Req any, Resp any, PResp any](
+	e endpoint.ClientStreamEndpoint[Req, Resp],
+	dec func(context.Context, PReq) (Req, error),
+	enc func(context.Context, Resp) (PResp, error),
+	options ...ServerOption,
+) *ClientStreamServer[PReq, Req, Resp, PResp] {
+	return &ClientStreamServer[PReq, Req, Resp, PResp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		newReq:  newMessageFunc[PReq]("NewClientStreamServer"),
+		options: newServerOptions(options),
+	}
+}
+
+// ServeGRPCStream handles the gRPC stream, receiving the requests and sending
+// the response.
+func (s ClientStreamServer[PReq, Req, Resp, PResp]) ServeGRPCStream(stream grpc.ServerStream) error {
+	return serveGRPCStream(stream, s.options, func(ctx context.Context, ss *serverStream) error {
+		response, err := s.e(ctx, recvFunc(ctx, stream, s.newReq, s.dec))
+		if err != nil {
+			return err
+		}
+		return sendFunc(ss, s.enc)(response)
+	})
+}
+
+// BidiStreamServer wraps a bidirectional streaming endpoint, decoding each
+// message received on the gRPC stream to a request and encoding each response
+// to a message sent on it.
+//
+// It follows the same semantics as ServerStreamServer for the before and after
+// metadata functions, errors and finalizers. As with gRPC streams, the
+// endpoint must not call send concurrently.
+type BidiStreamServer[PReq any, Req any, Resp any, PResp any] struct {
+	e       endpoint.BidiStreamEndpoint[Req, Resp]
+	dec     func(context.Context, PReq) (Req, error)
+	enc     func(context.Context, Resp) (PResp, error)
+	newReq  func() PReq
+	options serverOptions
+}
+
+// NewBidiStreamServer constructs a new bidirectional streaming server, which
+// wraps the provided endpoint. PReq must be a pointer to the protobuf message
+// of the RPC request type.
+func NewBidiStreamServer[PReq any, Req any, Resp any, PResp any](
+	e endpoint.BidiStreamEndpoint[Req, Resp],
+	dec func(context.Context, PReq) (Req, error),
+	enc func(context.Context, Resp) (PResp, error),
+	options ...ServerOption,
+) *BidiStreamServer[PReq, Req, Resp, PResp] {
+	return &BidiStreamServer[PReq, Req, Resp, PResp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		newReq:  newMessageFunc[PReq]("NewBidiStreamServer"),
+		options: newServerOptions(options),
+	}
+}
+
+// ServeGRPCStream handles the gRPC stream, receiving the requests and sending
+// the responses.
+func (s BidiStreamServer[PReq, Req, Resp, PResp]) ServeGRPCStream(stream grpc.ServerStream) error {
+	return serveGRPCStream(stream, s.options, func(ctx context.Context, ss *serverStream) error {
+		return s.e(ctx, recvFunc(ctx, stream, s.newReq, s.dec), sendFunc(ss, s.enc))
+	})
+}
+
+// serverStream runs the after functions once before the first response.
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	options serverOptions
+	started bool
+	trailer metadata.MD
+}
+
+// start runs the after functions if they didn't run yet, setting the response
+// header, and returns their context.
+func (ss *serverStream) start() (context.Context, error) {
+	if ss.started {
+		return ss.ctx, nil
+	}
+	ss.started = true
+	var mdHeader metadata.MD
+	for _, f := range ss.options.after {
+		ss.ctx = f(ss.ctx, &mdHeader, &ss.trailer)
+	}
+	if len(mdHeader) > 0 {
+		if err := ss.SetHeader(mdHeader); err != nil {
+			return ss.ctx, err
+		}
+	}
+	return ss.ctx, nil
+}
+
+// serveGRPCStream runs the parts common to the streaming servers: metadata
+// functions, finalizers and error handling.
+func serveGRPCStream(stream grpc.ServerStream, options serverOptions,
+	serve func(ctx context.Context, ss *serverStream) error) (err error) {
+	ctx := stream.Context()
+
+	// Retrieve gRPC metadata.
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+
+	ss := &serverStream{ServerStream: stream, ctx: ctx, options: options}
+
+	defer func() {
+		if err != nil {
+			err = options.errorEncoder(ss.ctx, err)
+		}
+	}()
+
+	if len(options.finalizer) > 0 {
+		defer func() {
+			for _, f := range options.finalizer {
+				f(ss.ctx, err)
+			}
+		}()
+	}
+
+	for _, f := range options.before {
+		ctx = f(ctx, md)
+	}
+	ss.ctx = ctx
+
+	err = serve(ctx, ss)
+	if err == nil {
+		_, err = ss.start()
+	}
+	if len(ss.trailer) > 0 {
+		ss.SetTrailer(ss.trailer)
+	}
+	if err != nil {
+		options.handleError(ss.ctx, err)
+	}
+	return err
+}
+
+func sendFunc[Resp any, PResp any](ss *serverStream, enc func(context.Context, Resp) (PResp, error)) func(Resp) error {
+	return func(response Resp) error {
+		ctx, err := ss.start()
+		if err != nil {
+			return err
+		}
+		msg, err := enc(ctx, response)
+		if err != nil {
+			return err
+		}
+		return ss.SendMsg(msg)
+	}
+}
+
+func recvFunc[PReq any, Req any](ctx context.Context, stream grpc.ServerStream, newReq func() PReq,
+	dec func(context.Context, PReq) (Req, error)) func() (Req, error) {
+	return func() (Req, error) {
+		msg := newReq()
+		if err := stream.RecvMsg(msg); err != nil {
+			var request Req
+			return request, err
+		}
+		return dec(ctx, msg)
+	}
+}
+
+// newMessageFunc returns a function which allocates a new message of the type
+// pointed by P, panicking if P is not a pointer.
+func newMessageFunc[P any](constructor string) func() P {
+	typ := reflect.TypeOf((*P)(nil)).Elem()
+	if typ.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("%s: message type %s must be a pointer", constructor, typ))
+	}
+	typ = typ.Elem()
+	return func() P {
+		return reflect.New(typ).Interface().(P)
+	}
+}
@@ -0,0 +1,185 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	grpctransport "github.com/RangelReale/go-kit-typed/transport/grpc"
+	test "github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test"
+)
+
+func startStreamClient(t *testing.T, consumedID *string) test.StreamClient {
+	cc := startBufconnServer(t, test.NewStreamBinding(test.NewService(), test.NewStreamService()))
+	return test.NewStreamClient(cc, grpctransport.ClientFinalizer(func(ctx context.Context, _ error) {
+		*consumedID = test.GetConsumedCorrelationID(ctx)
+	}))
+}
+
+func collectValues(responses *[]string) func(*test.TestResponse) error {
+	return func(resp *test.TestResponse) error {
+		*responses = append(*responses, resp.V)
+		return nil
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	var consumedID string
+	client := startStreamClient(t, &consumedID)
+	ctx := test.SetCorrelationID(context.Background(), "request-1")
+
+	var values []string
+	if err := client.Repeat(ctx, test.TestRequest{A: "a", B: 3}, collectValues(&values)); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"a = 1", "a = 2", "a = 3"}, values; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "request-1", consumedID; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	err := client.Repeat(ctx, test.TestRequest{A: "a", B: -1}, collectValues(&values))
+	if want, have := codes.InvalidArgument, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestServerStreamClientStop(t *testing.T) {
+	var consumedID string
+	client := startStreamClient(t, &consumedID)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err := client.Repeat(context.Background(), test.TestRequest{A: "a", B: 1000}, func(*test.TestResponse) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if want, have := errStop, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := 2, calls; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	var consumedID string
+	client := startStreamClient(t, &consumedID)
+	ctx := test.SetCorrelationID(context.Background(), "request-2")
+
+	resp, err := client.Join(ctx, endpoint.RecvSlice([]test.TestRequest{{A: "a", B: 1}, {A: "b", B: 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a = 1, b = 2", resp.V; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "request-2", test.GetConsumedCorrelationID(resp.Ctx); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	// plain errors are sent with the Unknown code.
+	_, err = client.Join(ctx, endpoint.RecvSlice([]test.TestRequest{{A: "a", B: 1}, {}}))
+	if want, have := codes.Unknown, status.Code(err); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "empty name", status.Convert(err).Message(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+
+	// recv errors cancel the stream.
+	errRecv := errors.New("recv")
+	_, err = client.Join(ctx, func() (test.TestRequest, error) { return test.TestRequest{}, errRecv })
+	if want, have := errRecv, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestBidiStream(t *testing.T) {
+	var consumedID string
+	client := startStreamClient(t, &consumedID)
+	ctx := test.SetCorrelationID(context.Background(), "request-3")
+
+	var values []string
+	requests := []test.TestRequest{{A: "a", B: 1}, {A: "b", B: 2}, {A: "c", B: 3}}
+	if err := client.Echo(ctx, endpoint.RecvSlice(requests), collectValues(&values)); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"a = 1", "b = 2", "c = 3"}, values; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "request-3", consumedID; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestBidiStreamInterleaved(t *testing.T) {
+	var consumedID string
+	client := startStreamClient(t, &consumedID)
+
+	// each request is only produced after the response of the previous one
+	// was received.
+	received := make(chan struct{}, 1)
+	received <- struct{}{}
+	n := int64(0)
+	recv := func() (test.TestRequest, error) {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			return test.TestRequest{}, errors.New("response not received")
+		}
+		n++
+		if n > 3 {
+			return test.TestRequest{}, io.EOF
+		}
+		return test.TestRequest{A: "x", B: n}, nil
+	}
+	var values []string
+	err := client.Echo(context.Background(), recv, func(resp *test.TestResponse) error {
+		values = append(values, resp.V)
+		received <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"x = 1", "x = 2", "x = 3"}, values; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	// recv errors cancel the stream.
+	errRecv := errors.New("recv")
+	err = client.Echo(context.Background(), func() (test.TestRequest, error) {
+		return test.TestRequest{}, errRecv
+	}, collectValues(&values))
+	if want, have := errRecv, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestDefaultErrorEncoder(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: status.Error(codes.NotFound, "nf"), code: codes.NotFound},
+		{err: context.Canceled, code: codes.Canceled},
+		{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{err: errors.New("other"), code: codes.Unknown},
+	} {
+		if want, have := tc.code, status.Code(grpctransport.DefaultErrorEncoder(context.Background(), tc.err)); want != have {
+			t.Errorf("%v: want %s, have %s", tc.err, want, have)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+// Binding sources, as used in struct tags and FieldError.Source.
+const (
+	BindSourceQuery  = "query"
+	BindSourcePath   = "path"
+	BindSourceHeader = "header"
+	BindSourceCookie = "cookie"
+)
+
+var bindSources = []string{BindSourceQuery, BindSourcePath, BindSourceHeader, BindSourceCookie}
+
+// FieldError describes a request value which could not be bound to a field.
+type FieldError struct {
+	// Field is the name of the struct field.
+	Field string
+	// Source is where the value was read from, one of the BindSource constants.
+	Source string
+	// Name is the name of the value in the source.
+	Name string
+	// Value is the raw value, if any.
+	Value string
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error implements error.
+func (e FieldError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s '%s': %s", e.Source, e.Name, e.Err)
+	}
+	return fmt.Sprintf("%s '%s': invalid value '%s': %s", e.Source, e.Name, e.Value, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// BindError is returned by BindRequest when one or more fields could not be
+// bound. It implements gokithttptransport.StatusCoder returning 400 Bad Request.
+type BindError struct {
+	Fields []FieldError
+}
+
+// Error implements error.
+func (e *BindError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// StatusCode implements gokithttptransport.StatusCoder.
+func (e *BindError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+var (
+	// ErrBindRequired is the FieldError.Err of required values which are missing.
+	ErrBindRequired = errors.New("value is required")
+)
+
+// BindOption sets an optional parameter for BindRequest.
+type BindOption func(*bindOptions)
+
+type bindOptions struct {
+	pathPattern []string
+	timeLayout  string
+	jsonBody    bool
+	jsonOptions util.JSONOptions
+}
+
+// BindPathPattern sets the pattern used to extract path parameters, like
+// "/profiles/{id}". If not set, the parameters of the route matched by a Router
+// are used.
+func BindPathPattern(pattern string) BindOption {
+	return func(o *bindOptions) { o.pathPattern = strings.Split(pattern, "/") }
+}
+
+// BindTimeLayout sets the layout used to parse time.Time fields. By default,
+// time.RFC3339 is used.
+func BindTimeLayout(layout string) BindOption {
+	return func(o *bindOptions) { o.timeLayout = layout }
+}
+
+// BindJSONBody decodes the request body as JSON before binding the tagged
+// fields, so the tagged values take precedence. An empty body is allowed.
+func BindJSONBody(options ...util.JSONOption) BindOption {
+	return func(o *bindOptions) {
+		o.jsonBody = true
+		o.jsonOptions = util.NewJSONOptions(options...)
+	}
+}
+
+// BindRequest returns a DecodeRequestFunc which populates the fields of Req,
+// a struct or a pointer to struct, from the request values named in the
+// "query", "path", "header" and "cookie" field tags:
+//
+//	type getProfilesRequest struct {
+//	    ID      int        `path:"id"`
+//	    Page    *int       `query:"page"`
+//	    Tags    []string   `query:"tag"`
+//	    Since   time.Time  `query:"since"`
+//	    Session string     `cookie:"session,required"`
+//	    Trace   string     `header:"X-Trace-Id"`
+//	}
+//
+// Strings, booleans, numbers, time.Duration, time.Time, encoding.TextUnmarshaler
+// implementations, and pointers and slices of them are supported. Slices
+// receive every value of repeated query parameters and headers. The
+// ",required" tag option fails the binding when the value is missing.
+//
+// Every field that fails to bind is reported in a BindError. BindRequest panics
+// if Req is not a struct or has unsupported tagged fields.
+func BindRequest[Req any](options ...BindOption) DecodeRequestFunc[Req] {
+	opts := bindOptions{timeLayout: time.RFC3339}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	var req Req
+	reqType := reflect.TypeOf(&req).Elem()
+	structType, isPtr := reqType, false
+	if structType.Kind() == reflect.Ptr {
+		structType, isPtr = structType.Elem(), true
+	}
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("BindRequest: type %s is not a struct", reqType))
+	}
+	fields, err := bindFields(structType, nil)
+	if err != nil {
+		panic(fmt.Sprintf("BindRequest: type %s: %s", reqType, err))
+	}
+
+	return func(ctx context.Context, r *http.Request) (Req, error) {
+		var ret Req
+		value := reflect.New(structType)
+		if opts.jsonBody {
+			if err := opts.jsonOptions.Decode(r.Body, value.Interface()); err != nil && err != io.EOF {
+				if errors.Is(err, util.ErrMessageTooLarge) {
+					return ret, NewStatusError(http.StatusRequestEntityTooLarge, err)
+				}
+				return ret, NewStatusError(http.StatusBadRequest, err)
+			}
+		}
+
+		var pathParams map[string]string
+		if opts.pathPattern != nil {
+			pathParams, _ = matchRoute(opts.pathPattern, strings.Split(r.URL.Path, "/"))
+		} else {
+			pathParams = PathParams(ctx)
+		}
+
+		var query map[string][]string
+		var bindErr BindError
+		for _, f := range fields {
+			var values []string
+			switch f.source {
+			case BindSourceQuery:
+				if query == nil {
+					query = r.URL.Query()
+				}
+				values = query[f.name]
+			case BindSourcePath:
+				if v, ok := pathParams[f.name]; ok {
+					values = []string{v}
+				}
+			case BindSourceHeader:
+				values = r.Header.Values(f.name)
+			case BindSourceCookie:
+				if c, err := r.Cookie(f.name); err == nil {
+					values = []string{c.Value}
+				}
+			}
+
+			fe := FieldError{Field: f.fieldName, Source: f.source, Name: f.name}
+			if len(values) == 0 {
+				if f.required {
+					fe.Err = ErrBindRequired
+					bindErr.Fields = append(bindErr.Fields, fe)
+				}
+				continue
+			}
+			if err := setBindValue(value.Elem().FieldByIndex(f.index), values, opts.timeLayout); err != nil {
+				var numErr *strconv.NumError
+				if errors.As(err, &numErr) {
+					err = numErr.Err
+				}
+				fe.Value, fe.Err = strings.Join(values, ","), err
+				bindErr.Fields = append(bindErr.Fields, fe)
+			}
+		}
+		if len(bindErr.Fields) > 0 {
+			return ret, &bindErr
+		}
+
+		if isPtr {
+			return value.Interface().(Req), nil
+		}
+		return value.Elem().Interface().(Req), nil
+	}
+}
+
+type bindField struct {
+	index     []int
+	fieldName string
+	source    string
+	name      string
+	required  bool
+}
+
+func bindFields(t reflect.Type, index []int) ([]bindField, error) {
+	var ret []bindField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			embedded, err := bindFields(sf.Type, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, embedded...)
+			continue
+		}
+		for _, source := range bindSources {
+			tag, ok := sf.Tag.Lookup(source)
+			if !ok || tag == "-" {
+				continue
+			}
+			if sf.PkgPath != "" {
+				return nil, fmt.Errorf("field %s is unexported", sf.Name)
+			}
+			if !isBindableType(sf.Type, true) {
+				return nil, fmt.Errorf("field %s has unsupported type %s", sf.Name, sf.Type)
+			}
+			name, opts, _ := cutString(tag, ",")
+			if name == "" {
+				name = sf.Name
+			}
+			ret = append(ret, bindField{
+				index:     fieldIndex,
+				fieldName: sf.Name,
+				source:    source,
+				name:      name,
+				required:  opts == "required",
+			})
+		}
+	}
+	return ret, nil
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func isBindableType(t reflect.Type, allowSlice bool) bool {
+	if t == timeType || t == durationType || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Ptr:
+		return isBindableType(t.Elem(), allowSlice)
+	case reflect.Slice:
+		return allowSlice && isBindableType(t.Elem(), false)
+	}
+	return false
+}
+
+func setBindValue(v reflect.Value, values []string, timeLayout string) error {
+	switch {
+	case v.Kind() == reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := setBindValue(elem.Elem(), values, timeLayout); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case v.Kind() == reflect.Slice && !v.Addr().Type().Implements(textUnmarshalerType):
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, s := range values {
+			if err := setBindValue(slice.Index(i), []string{s}, timeLayout); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	s := values[0]
+	switch {
+	case v.Type() == timeType:
+		t, err := time.Parse(timeLayout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case v.Addr().Type().Implements(textUnmarshalerType):
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func cutString(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,137 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+type bindPaging struct {
+	Page    int  `query:"page"`
+	PerPage *int `query:"per_page"`
+}
+
+type bindRequest struct {
+	bindPaging
+	ID      uint64        `path:"id"`
+	Tags    []string      `query:"tag"`
+	Weights []float64     `query:"w"`
+	Active  bool          `query:"active"`
+	Since   time.Time     `query:"since"`
+	Timeout time.Duration `query:"timeout"`
+	IP      net.IP        `query:"ip"`
+	Trace   string        `header:"X-Trace-Id"`
+	Session string        `cookie:"session,required"`
+	Name    string        `json:"name"`
+	Ignored string        `query:"-"`
+}
+
+func TestBindRequest(t *testing.T) {
+	decode := httptransport.BindRequest[bindRequest](httptransport.BindPathPattern("/profiles/{id}"))
+
+	req := httptest.NewRequest("GET",
+		"/profiles/42?page=3&per_page=10&tag=a&tag=b&w=1.5&w=2&active=true&since=2022-03-04T05:06:07Z&timeout=1m30s&ip=10.0.0.1&Ignored=x", nil)
+	req.Header.Set("X-Trace-Id", "trace-1")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+
+	have, err := decode(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perPage := 10
+	want := bindRequest{
+		bindPaging: bindPaging{Page: 3, PerPage: &perPage},
+		ID:         42,
+		Tags:       []string{"a", "b"},
+		Weights:    []float64{1.5, 2},
+		Active:     true,
+		Since:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+		Timeout:    90 * time.Second,
+		IP:         net.ParseIP("10.0.0.1"),
+		Trace:      "trace-1",
+		Session:    "s1",
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+}
+
+func TestBindRequestErrors(t *testing.T) {
+	decode := httptransport.BindRequest[*bindRequest](httptransport.BindPathPattern("/profiles/{id}"))
+
+	req := httptest.NewRequest("GET", "/profiles/abc?page=x&active=maybe&since=yesterday", nil)
+	_, err := decode(context.Background(), req)
+
+	var bindErr *httptransport.BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("want BindError, have %v", err)
+	}
+	if want, have := http.StatusBadRequest, bindErr.StatusCode(); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	var fields []string
+	for _, f := range bindErr.Fields {
+		fields = append(fields, f.Source+":"+f.Name)
+	}
+	if want, have := []string{"query:page", "path:id", "query:active", "query:since", "cookie:session"}, fields; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := httptransport.ErrBindRequired, bindErr.Fields[4].Err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "abc", bindErr.Fields[1].Value; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestBindRequestJSONBodyAndRouter(t *testing.T) {
+	type request struct {
+		ID   int    `path:"id"`
+		Name string `json:"name"`
+	}
+	type Endpoints struct {
+		PutProfile func(context.Context, request) (request, error) `http:"PUT /profiles/{id}" codec:"bind"`
+	}
+	decode := httptransport.BindRequest[request](httptransport.BindJSONBody())
+	router, err := httptransport.NewRouter(Endpoints{
+		PutProfile: func(_ context.Context, req request) (request, error) { return req, nil },
+	}, httptransport.RouterCodec("bind", httptransport.StructCodec{
+		Decode: func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := decode(ctx, r)
+			if err != nil {
+				return err
+			}
+			*v.(*request) = req
+			return nil
+		},
+		Encode: httptransport.JSONStructCodec.Encode,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("PUT", "/profiles/7", strings.NewReader(`{"name":"x"}`)))
+	if want, have := `{"ID":7,"name":"x"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestBindRequestInvalidType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	httptransport.BindRequest[struct {
+		Values map[string]string `query:"v"`
+	}]()
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"context"
+	"net/http"
 	"net/url"
 
 	"github.com/RangelReale/go-kit-typed/endpoint"
@@ -9,48 +11,48 @@ import (
 
 // Client wraps a URL and provides a method that implements endpoint.Endpoint.
 type Client[Req any, Resp any] struct {
-	client *gokithttptransport.Client
+	client        *gokithttptransport.Client
+	errorDecoders []clientErrorDecoder
+	after         []ClientAfterFunc[Resp]
+	finalizers    []ClientFinalizerTypedFunc[Req, Resp]
 }
 
 // NewClient constructs a usable Client for a single remote method.
 func NewClient[Req any, Resp any](method string, tgt *url.URL, enc EncodeRequestFunc[Req],
 	dec DecodeResponseFunc[Resp], options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewClient(
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewClient(
 		method,
 		tgt,
 		EncodeRequestFuncReverseAdapter(enc),
-		DecodeResponseFuncReverseAdapter(dec),
+		c.decoder(DecodeResponseFuncReverseAdapter(dec)),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
 }
 
 // NewClientStdEnc constructs a usable Client for a single remote method.
 func NewClientStdEnc[Req any, Resp any](method string, tgt *url.URL, enc gokithttptransport.EncodeRequestFunc,
 	dec DecodeResponseFunc[Resp], options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewClient(method,
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewClient(method,
 		tgt,
 		enc,
-		DecodeResponseFuncReverseAdapter(dec),
+		c.decoder(DecodeResponseFuncReverseAdapter(dec)),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
 }
 
 // NewClientStdDec constructs a usable Client for a single remote method.
 func NewClientStdDec[Req any, Resp any](method string, tgt *url.URL, enc EncodeRequestFunc[Req],
 	dec gokithttptransport.DecodeResponseFunc, options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewClient(
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewClient(
 		method,
 		tgt,
 		EncodeRequestFuncReverseAdapter(enc),
-		dec,
+		c.decoder(dec),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
 }
 
 // NewExplicitClient is like NewClient but uses a CreateRequestFunc instead of a
@@ -58,13 +60,12 @@ func NewClientStdDec[Req any, Resp any](method string, tgt *url.URL, enc EncodeR
 // the outgoing HTTP request.
 func NewExplicitClient[Req any, Resp any](req CreateRequestFunc[Req], dec DecodeResponseFunc[Resp],
 	options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewExplicitClient(
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewExplicitClient(
 		CreateRequestFuncReverseAdapter(req),
-		DecodeResponseFuncReverseAdapter(dec),
+		c.decoder(DecodeResponseFuncReverseAdapter(dec)),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
 }
 
 // NewExplicitClientStdCreate is like NewClient but uses a CreateRequestFunc instead of a
@@ -72,13 +73,12 @@ func NewExplicitClient[Req any, Resp any](req CreateRequestFunc[Req], dec Decode
 // the outgoing HTTP request, using the non-typed creator.
 func NewExplicitClientStdCreate[Req any, Resp any](req gokithttptransport.CreateRequestFunc, dec DecodeResponseFunc[Resp],
 	options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewExplicitClient(
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewExplicitClient(
 		req,
-		DecodeResponseFuncReverseAdapter(dec),
+		c.decoder(DecodeResponseFuncReverseAdapter(dec)),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
 }
 
 // NewExplicitClientStdDec is like NewClient but uses a CreateRequestFunc instead of a
@@ -86,15 +86,77 @@ func NewExplicitClientStdCreate[Req any, Resp any](req gokithttptransport.Create
 // the outgoing HTTP request, using the non-typed decoder.
 func NewExplicitClientStdDec[Req any, Resp any](req CreateRequestFunc[Req], dec gokithttptransport.DecodeResponseFunc,
 	options ...gokithttptransport.ClientOption) *Client[Req, Resp] {
-	client := gokithttptransport.NewExplicitClient(CreateRequestFuncReverseAdapter(req),
-		dec,
+	c := &Client[Req, Resp]{}
+	c.client = gokithttptransport.NewExplicitClient(CreateRequestFuncReverseAdapter(req),
+		c.decoder(dec),
 		options...)
-	return &Client[Req, Resp]{
-		client: client,
-	}
+	return c
+}
+
+// ErrorResponseDecoder makes the client decode responses whose status is in one
+// of the ranges with dec instead of the response decoder, like the
+// ClientErrorResponseDecoder option of NativeClient, which Client doesn't
+// accept. DecodeJSONErrorResponse and DecodeProblemErrorResponse can be passed
+// as dec for the ClientErrorResponse and ClientProblemResponse behaviors. When
+// more than one error decoder matches a status, the first one added is used.
+// Error decoders must be set before the endpoint is called.
+func (c *Client[Req, Resp]) ErrorResponseDecoder(dec ErrorResponseDecoder, ranges ...StatusRange) *Client[Req, Resp] {
+	c.errorDecoders = append(c.errorDecoders, newClientErrorDecoder(dec, ranges))
+	return c
+}
+
+// After adds functions which are executed after the response is successfully
+// decoded, and receive the HTTP response and the decoded response. They are
+// executed after the ClientAfter functions and the decoder, and must be set
+// before the endpoint is called.
+func (c *Client[Req, Resp]) After(after ...ClientAfterFunc[Resp]) *Client[Req, Resp] {
+	c.after = append(c.after, after...)
+	return c
+}
+
+// Finalizer adds functions which are executed at the end of every call, like
+// the Go kit ClientFinalizer option, and also receive the request, the decoded
+// response and the error of the call. They are executed after the
+// ClientFinalizer functions, and must be set before the endpoint is created.
+func (c *Client[Req, Resp]) Finalizer(f ...ClientFinalizerTypedFunc[Req, Resp]) *Client[Req, Resp] {
+	c.finalizers = append(c.finalizers, f...)
+	return c
 }
 
 // Endpoint returns a usable Go kit endpoint that calls the remote HTTP endpoint.
 func (c Client[Req, Resp]) Endpoint() endpoint.Endpoint[Req, Resp] {
-	return endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	e := endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	if len(c.finalizers) == 0 {
+		return e
+	}
+	return func(ctx context.Context, request Req) (response Resp, err error) {
+		defer func() {
+			for _, f := range c.finalizers {
+				f(ctx, request, response, err)
+			}
+		}()
+		return e(ctx, request)
+	}
+}
+
+// decoder wraps the decoder to run the error decoders and the typed after
+// functions, which are read when the response is decoded.
+func (c *Client[Req, Resp]) decoder(dec gokithttptransport.DecodeResponseFunc) gokithttptransport.DecodeResponseFunc {
+	return func(ctx context.Context, r *http.Response) (interface{}, error) {
+		for _, ed := range c.errorDecoders {
+			if statusInRanges(r.StatusCode, ed.ranges) {
+				var ret Resp
+				return ret, ed.decode(ctx, r)
+			}
+		}
+		response, err := dec(ctx, r)
+		if err != nil || len(c.after) == 0 {
+			return response, err
+		}
+		typed, _ := response.(Resp)
+		for _, f := range c.after {
+			f(ctx, r, typed)
+		}
+		return response, nil
+	}
 }
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+)
+
+// ResponseError is the error returned by DecodeErrorResponse and by clients
+// configured with an error response option, when the response status matches
+// one of its ranges. The decoded error is available with errors.As or
+// errors.Unwrap.
+//
+// Its errkind kind is the one of the Error-Kind header, or else the kind of
+// the status, so errors.Is(err, errkind.NotFound) works for errors returned by
+// servers.
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header is the header of the response.
+	Header http.Header
+	// Err is the error decoded from the response.
+	Err error
+}
+
+// Error implements error.
+func (e *ResponseError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("http status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Err)
+}
+
+// Unwrap returns the decoded error.
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKind implements errkind.Kinder.
+func (e *ResponseError) ErrorKind() errkind.Kind {
+	if kind := e.Header.Get(errkind.Header); kind != "" {
+		return errkind.Kind(kind)
+	}
+	return errkind.FromHTTPStatus(e.StatusCode)
+}
+
+// Is reports whether target is the errkind kind of the error.
+func (e *ResponseError) Is(target error) bool {
+	kind, ok := target.(errkind.Kind)
+	return ok && kind == e.ErrorKind()
+}
+
+// StatusRange is an inclusive range of HTTP status codes.
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+// Common status ranges.
+var (
+	StatusRangeClientError = StatusRange{Min: 400, Max: 499}
+	StatusRangeServerError = StatusRange{Min: 500, Max: 599}
+	StatusRangeNotSuccess  = []StatusRange{{Min: 100, Max: 199}, {Min: 300, Max: 599}}
+)
+
+// Contains returns whether the status code is in the range.
+func (r StatusRange) Contains(status int) bool {
+	return status >= r.Min && status <= r.Max
+}
+
+func statusInRanges(status int, ranges []StatusRange) bool {
+	for _, r := range ranges {
+		if r.Contains(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorResponseDecoder decodes an HTTP response into an error.
+type ErrorResponseDecoder func(ctx context.Context, r *http.Response) error
+
+// DecodeErrorResponse returns a DecodeResponseFunc which decodes responses
+// whose status is in one of the ranges with errDec, returning the decoded error
+// wrapped in a *ResponseError, and calls next for all other responses. With no
+// ranges, every status outside 2xx is matched. It can wrap its own result to
+// use different error decoders for different ranges, in which case the
+// outermost matching one is used.
+func DecodeErrorResponse[Resp any](next DecodeResponseFunc[Resp], errDec ErrorResponseDecoder,
+	ranges ...StatusRange) DecodeResponseFunc[Resp] {
+	ed := newClientErrorDecoder(errDec, ranges)
+	return func(ctx context.Context, r *http.Response) (Resp, error) {
+		if statusInRanges(r.StatusCode, ed.ranges) {
+			var ret Resp
+			return ret, ed.decode(ctx, r)
+		}
+		return next(ctx, r)
+	}
+}
+
+// ClientErrorResponseDecoder makes the client decode responses whose status is
+// in one of the ranges with dec instead of the response decoder, like
+// DecodeErrorResponse. When more than one error decoder matches a status, the
+// first one added is used. It is an option of NativeClient and, with
+// StreamClientOptions, of the streaming clients; Client uses its
+// ErrorResponseDecoder method or DecodeErrorResponse instead.
+func ClientErrorResponseDecoder(dec ErrorResponseDecoder, ranges ...StatusRange) NativeClientOption {
+	ed := newClientErrorDecoder(dec, ranges)
+	return func(c *clientOptions) { c.errorDecoders = append(c.errorDecoders, ed) }
+}
+
+// ClientErrorResponse is a ClientErrorResponseDecoder which decodes the JSON
+// response body into the error type E. If E is a pointer type, a new value is
+// allocated before decoding.
+func ClientErrorResponse[E error](ranges ...StatusRange) NativeClientOption {
+	return ClientErrorResponseDecoder(DecodeJSONErrorResponse[E], ranges...)
+}
+
+// ClientProblemResponse is a ClientErrorResponseDecoder which decodes the
+// response with DecodeProblem, returning a *Problem.
+func ClientProblemResponse(ranges ...StatusRange) NativeClientOption {
+	return ClientErrorResponseDecoder(DecodeProblemErrorResponse, ranges...)
+}
+
+type clientErrorDecoder struct {
+	dec    ErrorResponseDecoder
+	ranges []StatusRange
+}
+
+func newClientErrorDecoder(dec ErrorResponseDecoder, ranges []StatusRange) clientErrorDecoder {
+	if len(ranges) == 0 {
+		ranges = StatusRangeNotSuccess
+	}
+	return clientErrorDecoder{dec: dec, ranges: ranges}
+}
+
+func (ed clientErrorDecoder) decode(ctx context.Context, r *http.Response) error {
+	return &ResponseError{
+		StatusCode: r.StatusCode,
+		Header:     r.Header,
+		Err:        ed.dec(ctx, r),
+	}
+}
+
+// DecodeJSONErrorResponse is an ErrorResponseDecoder which decodes the JSON
+// response body into the error type E. If E is a pointer type, a new value is
+// allocated before decoding. An empty body returns the zero or newly allocated
+// value.
+func DecodeJSONErrorResponse[E error](_ context.Context, r *http.Response) error {
+	var e E
+	target := interface{}(&e)
+	if t := reflect.TypeOf(&e).Elem(); t.Kind() == reflect.Ptr {
+		v := reflect.New(t.Elem())
+		e = v.Interface().(E)
+		target = v.Interface()
+	}
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil && err != io.EOF {
+		return fmt.Errorf("decoding error response: %w", err)
+	}
+	return e
+}
+
+// DecodeTextErrorResponse is an ErrorResponseDecoder which returns the
+// response body as the error message, as written by DefaultErrorEncoder. The
+// errkind kind of the error is kept by the *ResponseError wrapping it.
+func DecodeTextErrorResponse(_ context.Context, r *http.Response) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return errors.New(strings.TrimSpace(string(body)))
+}
+
+// DecodeProblemErrorResponse is an ErrorResponseDecoder which decodes the
+// response with DecodeProblem, returning a *Problem.
+func DecodeProblemErrorResponse(_ context.Context, r *http.Response) error {
+	problem, err := DecodeProblem(r)
+	if err != nil {
+		return err
+	}
+	return problem
+}
@@ -0,0 +1,204 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string { return e.Code + ": " + e.Message }
+
+type clientErrorResponse struct {
+	Value string `json:"value"`
+}
+
+func newClientErrorServer(status int, contentType string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestClientErrorResponse(t *testing.T) {
+	server := newClientErrorServer(http.StatusConflict, "application/json", `{"code":"conflict","message":"already exists"}`)
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[struct{}],
+		httptransport.DecodeErrorResponse(httptransport.DecodeJSONResponse[clientErrorResponse],
+			httptransport.DecodeJSONErrorResponse[*apiError], httptransport.StatusRangeClientError),
+	)
+	_, err := client.Endpoint()(context.Background(), struct{}{})
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("want apiError, have %v", err)
+	}
+	if want, have := "conflict", apiErr.Code; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	var respErr *httptransport.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("want ResponseError, have %v", err)
+	}
+	if want, have := http.StatusConflict, respErr.StatusCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "req-1", respErr.Header.Get("X-Request-Id"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestClientErrorResponseRanges(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		status     int
+		wantErr    bool
+		wantResult string
+	}{
+		{"success", http.StatusOK, false, "ok"},
+		{"client error", http.StatusNotFound, true, ""},
+		{"server error not in range", http.StatusInternalServerError, false, "ok"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := newClientErrorServer(test.status, "application/json", `{"value":"ok"}`)
+			defer server.Close()
+
+			client := httptransport.NewClient(
+				"GET",
+				mustParse(server.URL),
+				httptransport.EncodeJSONRequest[struct{}],
+				httptransport.DecodeErrorResponse(httptransport.DecodeJSONResponse[clientErrorResponse],
+					httptransport.DecodeJSONErrorResponse[*apiError], httptransport.StatusRangeClientError),
+			)
+			resp, err := client.Endpoint()(context.Background(), struct{}{})
+			if want, have := test.wantErr, err != nil; want != have {
+				t.Fatalf("want error %v, have %v", want, err)
+			}
+			if want, have := test.wantResult, resp.Value; want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+		})
+	}
+}
+
+func TestClientProblemResponse(t *testing.T) {
+	server := newClientErrorServer(http.StatusServiceUnavailable, httptransport.ProblemContentType,
+		`{"title":"Service Unavailable","status":503,"detail":"maintenance"}`)
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"GET",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[struct{}],
+		httptransport.DecodeErrorResponse(
+			httptransport.DecodeErrorResponse(httptransport.DecodeJSONResponse[clientErrorResponse],
+				httptransport.DecodeProblemErrorResponse),
+			httptransport.DecodeJSONErrorResponse[*apiError], httptransport.StatusRangeClientError),
+	)
+	_, err := client.Endpoint()(context.Background(), struct{}{})
+
+	var problem *httptransport.Problem
+	if !errors.As(err, &problem) {
+		t.Fatalf("want Problem, have %v", err)
+	}
+	if want, have := "maintenance", problem.Detail; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "http status 503: Service Unavailable: maintenance", err.Error(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestNativeClientErrorResponseOptions(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		status int
+		check  func(t *testing.T, err error)
+	}{
+		{"client error", http.StatusConflict, func(t *testing.T, err error) {
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("want apiError, have %v", err)
+			}
+		}},
+		{"server error", http.StatusServiceUnavailable, func(t *testing.T, err error) {
+			var problem *httptransport.Problem
+			if !errors.As(err, &problem) {
+				t.Fatalf("want Problem, have %v", err)
+			}
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := newClientErrorServer(test.status, "application/json", `{"code":"conflict"}`)
+			defer server.Close()
+
+			client := httptransport.NewNativeClient(
+				"GET",
+				mustParse(server.URL),
+				httptransport.EncodeJSONRequest[struct{}],
+				httptransport.DecodeJSONResponse[clientErrorResponse],
+				httptransport.ClientErrorResponse[*apiError](httptransport.StatusRangeClientError),
+				httptransport.ClientProblemResponse(),
+			)
+			_, err := client.Endpoint()(context.Background(), struct{}{})
+			test.check(t, err)
+		})
+	}
+}
+
+func TestClientErrorResponseDecoderMethod(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		status int
+		check  func(t *testing.T, err error)
+	}{
+		{"client error", http.StatusConflict, func(t *testing.T, err error) {
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("want apiError, have %v", err)
+			}
+		}},
+		{"server error", http.StatusServiceUnavailable, func(t *testing.T, err error) {
+			var problem *httptransport.Problem
+			if !errors.As(err, &problem) {
+				t.Fatalf("want Problem, have %v", err)
+			}
+		}},
+		{"success", http.StatusOK, func(t *testing.T, err error) {
+			if err != nil {
+				t.Fatalf("want nil error, have %v", err)
+			}
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := newClientErrorServer(test.status, "application/json", `{"code":"conflict"}`)
+			defer server.Close()
+
+			client := httptransport.NewClient(
+				"GET",
+				mustParse(server.URL),
+				httptransport.EncodeJSONRequest[struct{}],
+				httptransport.DecodeJSONResponse[clientErrorResponse],
+			).
+				ErrorResponseDecoder(httptransport.DecodeJSONErrorResponse[*apiError],
+					httptransport.StatusRangeClientError).
+				ErrorResponseDecoder(httptransport.DecodeProblemErrorResponse)
+			_, err := client.Endpoint()(context.Background(), struct{}{})
+			test.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// NativeClientOption sets an optional parameter for native and stream clients.
+// Client accepts the Go kit client options instead.
+type NativeClientOption func(*clientOptions)
+
+type clientOptions struct {
+	client         gokithttptransport.HTTPClient
+	before         []gokithttptransport.RequestFunc
+	after          []gokithttptransport.ClientResponseFunc
+	finalizer      []gokithttptransport.ClientFinalizerFunc
+	bufferedStream bool
+	errorDecoders  []clientErrorDecoder
+}
+
+func newClientOptions(options []NativeClientOption) clientOptions {
+	copt := clientOptions{
+		client: http.DefaultClient,
+	}
+	for _, opt := range options {
+		opt(&copt)
+	}
+	return copt
+}
+
+// decodeError returns the error decoded by the first error decoder matching
+// the response status, or nil if none matches.
+func (c clientOptions) decodeError(ctx context.Context, r *http.Response) error {
+	for _, ed := range c.errorDecoders {
+		if statusInRanges(r.StatusCode, ed.ranges) {
+			return ed.decode(ctx, r)
+		}
+	}
+	return nil
+}
+
+// NativeSetClient sets the underlying HTTP client used for requests.
+// By default, http.DefaultClient is used.
+func NativeSetClient(client gokithttptransport.HTTPClient) NativeClientOption {
+	return func(c *clientOptions) { c.client = client }
+}
+
+// NativeClientBefore adds one or more RequestFuncs to be applied to the
+// outgoing HTTP request before it's invoked.
+func NativeClientBefore(before ...gokithttptransport.RequestFunc) NativeClientOption {
+	return func(c *clientOptions) { c.before = append(c.before, before...) }
+}
+
+// NativeClientAfter adds one or more ClientResponseFuncs, which are applied to
+// the incoming HTTP response prior to it being decoded. This is useful for
+// obtaining anything off of the response and adding it into the context prior
+// to decoding.
+func NativeClientAfter(after ...gokithttptransport.ClientResponseFunc) NativeClientOption {
+	return func(c *clientOptions) { c.after = append(c.after, after...) }
+}
+
+// NativeClientFinalizer adds one or more ClientFinalizerFuncs to be executed at
+// the end of every HTTP request. Finalizers are executed in the order in which
+// they were added. By default, no finalizer is registered.
+func NativeClientFinalizer(f ...gokithttptransport.ClientFinalizerFunc) NativeClientOption {
+	return func(c *clientOptions) { c.finalizer = append(c.finalizer, f...) }
+}
+
+// NativeBufferedStream sets whether the HTTP response body is left open,
+// allowing it to be read from later. Useful for transporting a file as a
+// buffered stream. That body has to be drained and closed to properly end the
+// request.
+func NativeBufferedStream(buffered bool) NativeClientOption {
+	return func(c *clientOptions) { c.bufferedStream = buffered }
+}
+
+// ClientAfterFunc is a typed after function, which receives the HTTP response
+// and the response returned by the decoder.
+type ClientAfterFunc[Resp any] func(ctx context.Context, r *http.Response, response Resp)
+
+// ClientFinalizerTypedFunc is a typed client finalizer, which receives the
+// request, the decoded response and the error of the call, if any.
+type ClientFinalizerTypedFunc[Req any, Resp any] func(ctx context.Context, request Req, response Resp, err error)
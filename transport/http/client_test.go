@@ -349,3 +349,76 @@ type httpClientFunc func(req *http.Request) (*http.Response, error)
 func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
+
+func TestClientFinalizerTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	type request struct {
+		ID string
+	}
+	type response struct {
+		Value string `json:"value"`
+	}
+
+	var (
+		finalizerReq  request
+		finalizerResp response
+		finalizerErr  error
+	)
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[request],
+		httptransport.DecodeJSONResponse[response],
+	).Finalizer(func(_ context.Context, req request, resp response, err error) {
+		finalizerReq, finalizerResp, finalizerErr = req, resp, err
+	})
+	if _, err := client.Endpoint()(context.Background(), request{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := "1", finalizerReq.ID; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "ok", finalizerResp.Value; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if finalizerErr != nil {
+		t.Errorf("want nil error, have %v", finalizerErr)
+	}
+}
+
+func TestClientAfterTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Value", "header")
+		_, _ = w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	type response struct {
+		Value string `json:"value"`
+	}
+
+	var afterHeader, afterValue string
+	client := httptransport.NewClient(
+		"GET",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[struct{}],
+		httptransport.DecodeJSONResponse[response],
+	).After(func(_ context.Context, r *http.Response, resp response) {
+		afterHeader, afterValue = r.Header.Get("X-Value"), resp.Value
+	})
+	if _, err := client.Endpoint()(context.Background(), struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := "header", afterHeader; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "ok", afterValue; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
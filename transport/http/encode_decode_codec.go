@@ -0,0 +1,104 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// DecodeRequestFuncFromCodec returns a DecodeRequestFunc which decodes the
+// request body using the codec. Decoding errors return a StatusError with 400
+// Bad Request.
+func DecodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) DecodeRequestFunc[Req] {
+	return func(_ context.Context, r *http.Request) (Req, error) {
+		var request Req
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return request, err
+		}
+		if err := c.Unmarshal(body, &request); err != nil {
+			var ret Req
+			return ret, NewStatusError(http.StatusBadRequest, err)
+		}
+		return request, nil
+	}
+}
+
+// EncodeResponseFuncFromCodec returns an EncodeResponseFunc which encodes the
+// response body using the codec, setting the Content-Type header to the codec
+// content type. If the response implements Headerer, the provided headers will
+// be applied to the response. If the response implements StatusCoder, the
+// provided StatusCode will be used instead of 200.
+func EncodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) EncodeResponseFunc[Resp] {
+	return func(_ context.Context, w http.ResponseWriter, response Resp) error {
+		return writeCodecResponse(w, c, response)
+	}
+}
+
+// EncodeRequestFuncFromCodec returns an EncodeRequestFunc which encodes the
+// request body using the codec, setting the Content-Type header to the codec
+// content type. If the request implements Headerer, the provided headers will
+// be applied to the request.
+func EncodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) EncodeRequestFunc[Req] {
+	return func(_ context.Context, r *http.Request, request Req) error {
+		body, err := c.Marshal(request)
+		if err != nil {
+			return err
+		}
+		r.Header.Set("Content-Type", c.ContentType())
+		if headerer, ok := any(request).(gokithttptransport.Headerer); ok {
+			for k := range headerer.Headers() {
+				r.Header.Set(k, headerer.Headers().Get(k))
+			}
+		}
+		r.ContentLength = int64(len(body))
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return nil
+	}
+}
+
+// DecodeResponseFuncFromCodec returns a DecodeResponseFunc which decodes the
+// response body using the codec.
+func DecodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) DecodeResponseFunc[Resp] {
+	return func(_ context.Context, r *http.Response) (Resp, error) {
+		var response Resp
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return response, err
+		}
+		if err := c.Unmarshal(body, &response); err != nil {
+			var ret Resp
+			return ret, err
+		}
+		return response, nil
+	}
+}
+
+func writeCodecResponse[T any](w http.ResponseWriter, c codec.Codec[T], response T) error {
+	body, err := c.Marshal(response)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", c.ContentType())
+	if headerer, ok := any(response).(gokithttptransport.Headerer); ok {
+		for k, values := range headerer.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := any(response).(gokithttptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,72 @@
+package http_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+func TestCodecServerClient(t *testing.T) {
+	for _, test := range []struct {
+		reqCodec    codec.Codec[*pb.TestRequest]
+		respCodec   codec.Codec[*pb.TestResponse]
+		contentType string
+	}{
+		{codec.Proto[*pb.TestRequest](), codec.Proto[*pb.TestResponse](), "application/x-protobuf"},
+		{codec.ProtoJSON[*pb.TestRequest](), codec.ProtoJSON[*pb.TestResponse](), "application/json; charset=utf-8"},
+		{codec.JSON[*pb.TestRequest](), codec.JSON[*pb.TestResponse](), "application/json; charset=utf-8"},
+	} {
+		var contentType string
+		handler := httptransport.NewServer(
+			func(_ context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
+				return &pb.TestResponse{V: strings.Repeat(req.A, int(req.B))}, nil
+			},
+			httptransport.DecodeRequestFuncFromCodec(test.reqCodec),
+			httptransport.EncodeResponseFuncFromCodec(test.respCodec),
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			handler.ServeHTTP(w, r)
+		}))
+
+		client := httptransport.NewClient(
+			"POST",
+			mustParse(server.URL),
+			httptransport.EncodeRequestFuncFromCodec(test.reqCodec),
+			httptransport.DecodeResponseFuncFromCodec(test.respCodec),
+		)
+
+		resp, err := client.Endpoint()(context.Background(), &pb.TestRequest{A: "ab", B: 2})
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, have := "abab", resp.V; want != have {
+			t.Errorf("want %s, have %s", want, have)
+		}
+		if want, have := test.contentType, contentType; want != have {
+			t.Errorf("want %s, have %s", want, have)
+		}
+	}
+}
+
+func TestCodecDecodeError(t *testing.T) {
+	handler := httptransport.NewServer(
+		func(_ context.Context, req jsonProfile) (jsonProfile, error) { return req, nil },
+		httptransport.DecodeRequestFuncFromCodec(codec.JSON[jsonProfile]()),
+		httptransport.EncodeResponseFuncFromCodec(codec.JSON[jsonProfile]()),
+	)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader("{")))
+	buf, _ := ioutil.ReadAll(rec.Body)
+	if want, have := http.StatusBadRequest, rec.Code; want != have {
+		t.Errorf("want %d, have %d (%s)", want, have, buf)
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// EncodeJSONRequest is an EncodeRequestFunc that serializes the request as a
+// JSON object to the Request body. If the request implements Headerer, the
+// provided headers will be applied to the request.
+func EncodeJSONRequest[Req any](ctx context.Context, r *http.Request, request Req) error {
+	return gokithttptransport.EncodeJSONRequest(ctx, r, request)
+}
+
+// EncodeJSONResponse is an EncodeResponseFunc that serializes the response as a
+// JSON object to the ResponseWriter. If the response implements Headerer, the
+// provided headers will be applied to the response. If the response implements
+// StatusCoder, the provided StatusCode will be used instead of 200.
+func EncodeJSONResponse[Resp any](ctx context.Context, w http.ResponseWriter, response Resp) error {
+	return gokithttptransport.EncodeJSONResponse(ctx, w, response)
+}
+
+// DecodeJSONRequest is a DecodeRequestFunc that deserializes the JSON request
+// body into the request type. An empty body returns the zero value.
+func DecodeJSONRequest[Req any](_ context.Context, r *http.Request) (Req, error) {
+	return decodeJSONRequest[Req](r, util.JSONOptions{})
+}
+
+// MakeDecodeJSONRequest returns a DecodeJSONRequest using the passed options.
+// Invalid JSON returns a StatusError with 400 Bad Request, and bodies larger
+// than the maximum size a StatusError with 413 Request Entity Too Large.
+func MakeDecodeJSONRequest[Req any](options ...util.JSONOption) DecodeRequestFunc[Req] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, r *http.Request) (Req, error) {
+		return decodeJSONRequest[Req](r, opts)
+	}
+}
+
+// DecodeJSONResponse is a DecodeResponseFunc that deserializes the JSON
+// response body into the response type. An empty body returns the zero value.
+func DecodeJSONResponse[Resp any](_ context.Context, r *http.Response) (Resp, error) {
+	return decodeJSONResponse[Resp](r, util.JSONOptions{})
+}
+
+// MakeDecodeJSONResponse returns a DecodeJSONResponse using the passed options.
+func MakeDecodeJSONResponse[Resp any](options ...util.JSONOption) DecodeResponseFunc[Resp] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, r *http.Response) (Resp, error) {
+		return decodeJSONResponse[Resp](r, opts)
+	}
+}
+
+func decodeJSONRequest[Req any](r *http.Request, options util.JSONOptions) (Req, error) {
+	var request Req
+	if err := options.Decode(r.Body, &request); err != nil && err != io.EOF {
+		var ret Req
+		if errors.Is(err, util.ErrMessageTooLarge) {
+			return ret, NewStatusError(http.StatusRequestEntityTooLarge, err)
+		}
+		return ret, NewStatusError(http.StatusBadRequest, err)
+	}
+	return request, nil
+}
+
+func decodeJSONResponse[Resp any](r *http.Response, options util.JSONOptions) (Resp, error) {
+	var response Resp
+	if err := options.Decode(r.Body, &response); err != nil && err != io.EOF {
+		var ret Resp
+		return ret, err
+	}
+	return response, nil
+}
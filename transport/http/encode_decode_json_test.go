@@ -0,0 +1,78 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+	"github.com/RangelReale/go-kit-typed/util"
+)
+
+type jsonProfile struct {
+	Name string `json:"name"`
+}
+
+func TestJSONServerClient(t *testing.T) {
+	handler := httptransport.NewServer(
+		func(_ context.Context, req jsonProfile) (jsonProfile, error) {
+			return jsonProfile{Name: strings.ToUpper(req.Name)}, nil
+		},
+		httptransport.MakeDecodeJSONRequest[jsonProfile](util.JSONDisallowUnknownFields(), util.JSONMaxBytes(32)),
+		httptransport.EncodeJSONResponse[jsonProfile],
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[jsonProfile],
+		httptransport.DecodeJSONResponse[jsonProfile],
+	)
+
+	resp, err := client.Endpoint()(context.Background(), jsonProfile{Name: "john"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "JOHN", resp.Name; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	for _, test := range []struct {
+		body   string
+		status int
+	}{
+		{`{"name": "john"}`, http.StatusOK},
+		{``, http.StatusOK},
+		{`{"name": "john", "age": 12}`, http.StatusBadRequest},
+		{`{"name": "`, http.StatusBadRequest},
+		{`{"name": "` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+	} {
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if want, have := test.status, resp.StatusCode; want != have {
+			t.Errorf("%s: want %d, have %d (%s)", test.body, want, have, buf)
+		}
+	}
+}
+
+func TestDecodeJSONRequestStatus(t *testing.T) {
+	_, err := httptransport.DecodeJSONRequest[jsonProfile](context.Background(),
+		httptest.NewRequest("POST", "/", strings.NewReader("{")))
+	var statusErr *httptransport.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected StatusError, received %v", err)
+	}
+	if want, have := http.StatusBadRequest, statusErr.StatusCode(); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// StatusError is an error which carries the HTTP status code to be returned to
+// the client. It implements gokithttptransport.StatusCoder, so it is honored by
+// DefaultErrorEncoder.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+// NewStatusError returns a StatusError with the passed code and error.
+func NewStatusError(code int, err error) *StatusError {
+	return &StatusError{Code: code, Err: err}
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements gokithttptransport.StatusCoder.
+func (e *StatusError) StatusCode() int {
+	return e.Code
+}
+
+// DefaultErrorEncoder writes the error like gokithttptransport.DefaultErrorEncoder,
+// also encoding its errkind kind. The kind of errors with a kind other than
+// errkind.Unknown is sent in the Error-Kind header, and when they don't
+// implement StatusCoder, the status of the kind is used instead of 500.
+func DefaultErrorEncoder(_ context.Context, err error, w http.ResponseWriter) {
+	contentType, body := "text/plain; charset=utf-8", []byte(err.Error())
+	if marshaler, ok := err.(json.Marshaler); ok {
+		if jsonBody, marshalErr := marshaler.MarshalJSON(); marshalErr == nil {
+			contentType, body = "application/json; charset=utf-8", jsonBody
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	if headerer, ok := err.(gokithttptransport.Headerer); ok {
+		for k, values := range headerer.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	w.WriteHeader(errorStatus(err, w.Header()))
+	_, _ = w.Write(body)
+}
+
+// errorStatus returns the status of an error, from StatusCoder or its kind,
+// and sets the Error-Kind header for errors with a kind.
+func errorStatus(err error, header http.Header) int {
+	code := http.StatusInternalServerError
+	kind := errkind.KindOf(err)
+	if kind != errkind.Unknown {
+		header.Set(errkind.Header, string(kind))
+		code = errkind.HTTPStatus(kind)
+	}
+	if sc, ok := err.(gokithttptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	return code
+}
@@ -0,0 +1,215 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/errkind"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+func TestDefaultErrorEncoder(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		err    error
+		status int
+		kind   string
+	}{
+		{"plain", errors.New("dang"), http.StatusInternalServerError, ""},
+		{"kind", errkind.New(errkind.NotFound, "dang"), http.StatusNotFound, "not_found"},
+		{"wrapped kind", fmt.Errorf("op: %w", errkind.Unauthenticated), http.StatusUnauthorized, "unauthenticated"},
+		{"status coder", httptransport.NewStatusError(http.StatusUnprocessableEntity, errkind.Conflict),
+			http.StatusUnprocessableEntity, "conflict"},
+		{"canceled", context.Canceled, 499, "canceled"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			httptransport.DefaultErrorEncoder(context.Background(), tc.err, rec)
+			if want, have := tc.status, rec.Code; want != have {
+				t.Errorf("want %d, have %d", want, have)
+			}
+			if want, have := tc.kind, rec.Header().Get(errkind.Header); want != have {
+				t.Errorf("want %q, have %q", want, have)
+			}
+			if want, have := tc.err.Error(), rec.Body.String(); want != have {
+				t.Errorf("want %q, have %q", want, have)
+			}
+		})
+	}
+}
+
+func TestErrorKindRoundTrip(t *testing.T) {
+	e := func(_ context.Context, req nativeRequest) (nativeResponse, error) {
+		return nativeResponse{}, errkind.New(errkind.Kind(req.A), "dang")
+	}
+	servers := map[string]http.Handler{
+		"server": httptransport.NewServer(e, httptransport.DecodeJSONRequest[nativeRequest],
+			httptransport.EncodeJSONResponse[nativeResponse]),
+		"native server": httptransport.NewNativeServer(e, httptransport.DecodeJSONRequest[nativeRequest],
+			httptransport.EncodeJSONResponse[nativeResponse]),
+	}
+	for serverName, handler := range servers {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		clients := map[string]endpoint.Endpoint[nativeRequest, nativeResponse]{
+			"client": httptransport.NewClient("POST", mustParse(server.URL),
+				httptransport.EncodeJSONRequest[nativeRequest],
+				httptransport.DecodeErrorResponse(httptransport.DecodeJSONResponse[nativeResponse],
+					httptransport.DecodeTextErrorResponse)).Endpoint(),
+			"native client": httptransport.NewNativeClient("POST", mustParse(server.URL),
+				httptransport.EncodeJSONRequest[nativeRequest],
+				httptransport.DecodeJSONResponse[nativeResponse],
+				httptransport.ClientErrorResponseDecoder(httptransport.DecodeTextErrorResponse)).Endpoint(),
+		}
+		for clientName, client := range clients {
+			t.Run(serverName+"/"+clientName, func(t *testing.T) {
+				for _, kind := range []errkind.Kind{errkind.NotFound, errkind.AlreadyExists, errkind.Internal} {
+					_, err := client(context.Background(), nativeRequest{A: string(kind)})
+					if !errors.Is(err, kind) {
+						t.Errorf("want errors.Is %q, have %v", kind, err)
+					}
+					if want, have := kind, errkind.KindOf(err); want != have {
+						t.Errorf("want %q, have %q", want, have)
+					}
+					var respErr *httptransport.ResponseError
+					if !errors.As(err, &respErr) {
+						t.Fatalf("want *ResponseError, have %T", err)
+					}
+					if want, have := errkind.HTTPStatus(kind), respErr.StatusCode; want != have {
+						t.Errorf("want %d, have %d", want, have)
+					}
+					if want, have := "dang", respErr.Err.Error(); want != have {
+						t.Errorf("want %q, have %q", want, have)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestErrorKindWithoutErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(errkind.Header, string(errkind.NotFound))
+		_, _ = w.Write([]byte(`{"v":"found"}`))
+	}))
+	defer server.Close()
+
+	clients := map[string]endpoint.Endpoint[nativeRequest, nativeResponse]{
+		"client": httptransport.NewClient("POST", mustParse(server.URL),
+			httptransport.EncodeJSONRequest[nativeRequest],
+			httptransport.DecodeJSONResponse[nativeResponse]).Endpoint(),
+		"native client": httptransport.NewNativeClient("POST", mustParse(server.URL),
+			httptransport.EncodeJSONRequest[nativeRequest],
+			httptransport.DecodeJSONResponse[nativeResponse]).Endpoint(),
+	}
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			resp, err := client(context.Background(), nativeRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, have := "found", resp.V; want != have {
+				t.Errorf("want %q, have %q", want, have)
+			}
+		})
+	}
+}
+
+func TestResponseErrorKind(t *testing.T) {
+	err := &httptransport.ResponseError{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	if !errors.Is(err, errkind.PermissionDenied) {
+		t.Errorf("want errors.Is %q", errkind.PermissionDenied)
+	}
+	err.Header.Set(errkind.Header, string(errkind.Unauthenticated))
+	if want, have := errkind.Unauthenticated, errkind.KindOf(err); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestNDJSONErrorKind(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewNDJSONServer(
+		func(_ context.Context, _ sseRequest, send func(sseResponse) error) error {
+			if err := send(sseResponse{N: 1}); err != nil {
+				return err
+			}
+			return errkind.New(errkind.Unavailable, "gone")
+		},
+		decodeSSERequest))
+	defer server.Close()
+
+	client := httptransport.NewNDJSONClient[sseRequest, sseResponse]("GET", mustParse(server.URL), encodeSSERequest)
+	_, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{})
+	var streamErr *httptransport.StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("want *StreamError, have %v", err)
+	}
+	if !errors.Is(err, errkind.Unavailable) {
+		t.Errorf("want errors.Is %q, have %q", errkind.Unavailable, streamErr.Kind)
+	}
+}
+
+func TestSSEErrorKind(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewSSEServer(
+		func(_ context.Context, _ sseRequest, send func(sseResponse) error) error {
+			if err := send(sseResponse{N: 1}); err != nil {
+				return err
+			}
+			return errkind.New(errkind.Unavailable, "gone")
+		},
+		decodeSSERequest,
+		encodeSSEResponse))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse])
+	_, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{})
+	var streamErr *httptransport.StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("want *StreamError, have %v", err)
+	}
+	if want, have := "gone", streamErr.Message; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if !errors.Is(err, errkind.Unavailable) {
+		t.Errorf("want errors.Is %q, have %q", errkind.Unavailable, streamErr.Kind)
+	}
+}
+
+func TestSSEErrorWithoutKind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", httptransport.SSEContentType)
+		_, _ = w.Write([]byte("event: error\ndata: gone\n\n"))
+	}))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse])
+	_, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{})
+	var streamErr *httptransport.StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("want *StreamError, have %v", err)
+	}
+	if want, have := "gone", streamErr.Message; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := errkind.Unknown, streamErr.ErrorKind(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestProblemErrorEncoderKind(t *testing.T) {
+	rec := httptest.NewRecorder()
+	httptransport.ProblemErrorEncoder(context.Background(), errkind.New(errkind.ResourceExhausted, "slow down"), rec)
+	if want, have := http.StatusTooManyRequests, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := string(errkind.ResourceExhausted), rec.Header().Get(errkind.Header); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/RangelReale/go-kit-typed/endpoint"
@@ -30,8 +31,15 @@ func NewClient[Req any, Resp any](
 }
 
 // Endpoint returns a usable Go kit endpoint that calls the remote HTTP endpoint.
+// JSON-RPC errors returned by the response decoder have the errkind kind of
+// their code, so errors.Is(err, errkind.NotFound) works for errors returned by
+// servers.
 func (c Client[Req, Resp]) Endpoint() endpoint.Endpoint[Req, Resp] {
-	return endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	e := endpoint.Adapter[Req, Resp](c.client.Endpoint())
+	return func(ctx context.Context, request Req) (Resp, error) {
+		response, err := e(ctx, request)
+		return response, decodeErrorKind(err)
+	}
 }
 
 type clientOptions struct {
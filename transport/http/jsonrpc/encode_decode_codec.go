@@ -0,0 +1,61 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+// The JSON RPC params and results are embedded in the JSON RPC envelope, so
+// the codecs used with these functions must generate JSON, like codec.JSON or
+// codec.ProtoJSON.
+
+// DecodeRequestFuncFromCodec returns a DecodeRequestFunc which decodes the
+// request params using the codec. Decoding errors return an InvalidParamsError.
+func DecodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) DecodeRequestFunc[Req] {
+	return func(_ context.Context, params json.RawMessage) (Req, error) {
+		var request Req
+		if err := c.Unmarshal(params, &request); err != nil {
+			var ret Req
+			return ret, gokitjsonrpctransport.Error{
+				Code:    gokitjsonrpctransport.InvalidParamsError,
+				Message: err.Error(),
+			}
+		}
+		return request, nil
+	}
+}
+
+// EncodeResponseFuncFromCodec returns an EncodeResponseFunc which encodes the
+// response result using the codec.
+func EncodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) EncodeResponseFunc[Resp] {
+	return func(_ context.Context, response Resp) (json.RawMessage, error) {
+		return c.Marshal(response)
+	}
+}
+
+// EncodeRequestFuncFromCodec returns an EncodeRequestFunc which encodes the
+// request params using the codec.
+func EncodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) EncodeRequestFunc[Req] {
+	return func(_ context.Context, request Req) (json.RawMessage, error) {
+		return c.Marshal(request)
+	}
+}
+
+// DecodeResponseFuncFromCodec returns a DecodeResponseFunc which decodes the
+// response result using the codec, or returns the response error, if found.
+func DecodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) DecodeResponseFunc[Resp] {
+	return func(_ context.Context, response gokitjsonrpctransport.Response) (Resp, error) {
+		var ret Resp
+		if response.Error != nil {
+			return ret, *response.Error
+		}
+		if err := c.Unmarshal(response.Result, &ret); err != nil {
+			var zero Resp
+			return zero, err
+		}
+		return ret, nil
+	}
+}
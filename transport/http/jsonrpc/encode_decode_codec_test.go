@@ -0,0 +1,46 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+	"github.com/RangelReale/go-kit-typed/transport/http/jsonrpc"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+func TestCodecServerClient(t *testing.T) {
+	var (
+		reqCodec  = codec.ProtoJSON[*pb.TestRequest]()
+		respCodec = codec.ProtoJSON[*pb.TestResponse]()
+	)
+
+	ecm := gokitjsonrpctransport.EndpointCodecMap{
+		"test": jsonrpc.MakeEndpointCodec(
+			func(_ context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
+				return &pb.TestResponse{V: req.A}, nil
+			},
+			jsonrpc.DecodeRequestFuncFromCodec(reqCodec),
+			jsonrpc.EncodeResponseFuncFromCodec(respCodec),
+		),
+	}
+	server := httptest.NewServer(jsonrpc.NewServer[*pb.TestRequest, *pb.TestResponse](ecm))
+	defer server.Close()
+
+	client := jsonrpc.NewClient[*pb.TestRequest, *pb.TestResponse](
+		mustParse(server.URL),
+		"test",
+		jsonrpc.ClientRequestEncoder(jsonrpc.EncodeRequestFuncFromCodec(reqCodec)),
+		jsonrpc.ClientResponseDecoder(jsonrpc.DecodeResponseFuncFromCodec(respCodec)),
+	)
+
+	resp, err := client.Endpoint()(context.Background(), &pb.TestRequest{A: "value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "value", resp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
@@ -0,0 +1,75 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+// EncodeJSONRequest is an EncodeRequestFunc that marshals the request params
+// to JSON.
+func EncodeJSONRequest[Req any](_ context.Context, request Req) (json.RawMessage, error) {
+	return json.Marshal(request)
+}
+
+// EncodeJSONResponse is an EncodeResponseFunc that marshals the response result
+// to JSON.
+func EncodeJSONResponse[Resp any](_ context.Context, response Resp) (json.RawMessage, error) {
+	return json.Marshal(response)
+}
+
+// DecodeJSONRequest is a DecodeRequestFunc that unmarshals the request params
+// into the request type. Empty params return the zero value, and invalid params
+// return an InvalidParamsError.
+func DecodeJSONRequest[Req any](_ context.Context, params json.RawMessage) (Req, error) {
+	return decodeJSONRequest[Req](params, util.JSONOptions{})
+}
+
+// MakeDecodeJSONRequest returns a DecodeJSONRequest using the passed options.
+func MakeDecodeJSONRequest[Req any](options ...util.JSONOption) DecodeRequestFunc[Req] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, params json.RawMessage) (Req, error) {
+		return decodeJSONRequest[Req](params, opts)
+	}
+}
+
+// DecodeJSONResponse is a DecodeResponseFunc that unmarshals the response result
+// into the response type, or returns the response error, if found.
+func DecodeJSONResponse[Resp any](_ context.Context, response gokitjsonrpctransport.Response) (Resp, error) {
+	return decodeJSONResponse[Resp](response, util.JSONOptions{})
+}
+
+// MakeDecodeJSONResponse returns a DecodeJSONResponse using the passed options.
+func MakeDecodeJSONResponse[Resp any](options ...util.JSONOption) DecodeResponseFunc[Resp] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, response gokitjsonrpctransport.Response) (Resp, error) {
+		return decodeJSONResponse[Resp](response, opts)
+	}
+}
+
+func decodeJSONRequest[Req any](params json.RawMessage, options util.JSONOptions) (Req, error) {
+	var request Req
+	if err := options.Unmarshal(params, &request); err != nil && err != io.EOF {
+		var ret Req
+		return ret, gokitjsonrpctransport.Error{
+			Code:    gokitjsonrpctransport.InvalidParamsError,
+			Message: err.Error(),
+		}
+	}
+	return request, nil
+}
+
+func decodeJSONResponse[Resp any](response gokitjsonrpctransport.Response, options util.JSONOptions) (Resp, error) {
+	var ret Resp
+	if response.Error != nil {
+		return ret, *response.Error
+	}
+	if err := options.Unmarshal(response.Result, &ret); err != nil && err != io.EOF {
+		var zero Resp
+		return zero, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,88 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/transport/http/jsonrpc"
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+type addRequest struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type addResponse struct {
+	V int `json:"v"`
+}
+
+func TestJSONServerClient(t *testing.T) {
+	ecm := gokitjsonrpctransport.EndpointCodecMap{
+		"add": jsonrpc.MakeEndpointCodec(
+			func(_ context.Context, req addRequest) (addResponse, error) {
+				return addResponse{V: req.A + req.B}, nil
+			},
+			jsonrpc.MakeDecodeJSONRequest[addRequest](util.JSONDisallowUnknownFields()),
+			jsonrpc.EncodeJSONResponse[addResponse],
+		),
+	}
+	server := httptest.NewServer(jsonrpc.NewServer[addRequest, addResponse](ecm))
+	defer server.Close()
+
+	client := jsonrpc.NewClient[addRequest, addResponse](
+		mustParse(server.URL),
+		"add",
+		jsonrpc.ClientRequestEncoder(jsonrpc.EncodeJSONRequest[addRequest]),
+		jsonrpc.ClientResponseDecoder(jsonrpc.DecodeJSONResponse[addResponse]),
+	)
+
+	resp, err := client.Endpoint()(context.Background(), addRequest{A: 2, B: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 5, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	type badRequest struct {
+		C int `json:"c"`
+	}
+	badClient := jsonrpc.NewClient[badRequest, addResponse](
+		mustParse(server.URL),
+		"add",
+		jsonrpc.ClientRequestEncoder(jsonrpc.EncodeJSONRequest[badRequest]),
+		jsonrpc.ClientResponseDecoder(jsonrpc.DecodeJSONResponse[addResponse]),
+	)
+	_, err = badClient.Endpoint()(context.Background(), badRequest{C: 1})
+	var rpcErr gokitjsonrpctransport.Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("expected jsonrpc Error, received %v", err)
+	}
+	if want, have := gokitjsonrpctransport.InvalidParamsError, rpcErr.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+func TestDecodeJSONResponseError(t *testing.T) {
+	_, err := jsonrpc.DecodeJSONResponse[addResponse](context.Background(), gokitjsonrpctransport.Response{
+		Error: &gokitjsonrpctransport.Error{Code: gokitjsonrpctransport.InternalError, Message: "dang"},
+	})
+	if err == nil || err.Error() != "dang" {
+		t.Errorf("expected error 'dang', received %v", err)
+	}
+
+	resp, err := jsonrpc.DecodeJSONResponse[addResponse](context.Background(), gokitjsonrpctransport.Response{
+		Result: json.RawMessage(`{"v": 3}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, resp.V; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
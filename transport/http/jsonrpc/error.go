@@ -0,0 +1,112 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+// kindError is an error with an errkind kind, which implements
+// gokitjsonrpctransport.ErrorCoder with the JSON-RPC code of the kind.
+type kindError struct {
+	err  error
+	code int
+}
+
+func (e kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e kindError) Unwrap() error {
+	return e.err
+}
+
+// ErrorCode implements gokitjsonrpctransport.ErrorCoder.
+func (e kindError) ErrorCode() int {
+	return e.code
+}
+
+// encodeErrorKind returns errors with a kind other than errkind.Unknown as an
+// error with the JSON-RPC code of the kind, unless they implement
+// gokitjsonrpctransport.ErrorCoder.
+func encodeErrorKind(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(gokitjsonrpctransport.ErrorCoder); ok {
+		return err
+	}
+	kind := errkind.KindOf(err)
+	if kind == errkind.Unknown {
+		return err
+	}
+	return kindError{err: err, code: errkind.JSONRPCCode(kind)}
+}
+
+// responseError is a JSON-RPC error returned by clients, which has the
+// errkind kind of its code.
+type responseError struct {
+	err gokitjsonrpctransport.Error
+}
+
+func (e responseError) Error() string {
+	return e.err.Error()
+}
+
+func (e responseError) Unwrap() error {
+	return e.err
+}
+
+// ErrorCode implements gokitjsonrpctransport.ErrorCoder.
+func (e responseError) ErrorCode() int {
+	return e.err.Code
+}
+
+// ErrorKind implements errkind.Kinder.
+func (e responseError) ErrorKind() errkind.Kind {
+	return errkind.FromJSONRPCCode(e.err.Code)
+}
+
+// Is reports whether target is the errkind kind of the error.
+func (e responseError) Is(target error) bool {
+	kind, ok := target.(errkind.Kind)
+	return ok && kind == e.ErrorKind()
+}
+
+// decodeErrorKind returns JSON-RPC errors as a responseError.
+func decodeErrorKind(err error) error {
+	if rpcErr, ok := err.(gokitjsonrpctransport.Error); ok {
+		return responseError{err: rpcErr}
+	}
+	return err
+}
+
+// errorKindCodecs returns the codecs with their errors encoded by
+// encodeErrorKind.
+func errorKindCodecs(ecm gokitjsonrpctransport.EndpointCodecMap) gokitjsonrpctransport.EndpointCodecMap {
+	ret := make(gokitjsonrpctransport.EndpointCodecMap, len(ecm))
+	for method, codec := range ecm {
+		if e := codec.Endpoint; e != nil {
+			codec.Endpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+				response, err := e(ctx, request)
+				return response, encodeErrorKind(err)
+			}
+		}
+		if dec := codec.Decode; dec != nil {
+			codec.Decode = func(ctx context.Context, msg json.RawMessage) (interface{}, error) {
+				request, err := dec(ctx, msg)
+				return request, encodeErrorKind(err)
+			}
+		}
+		if enc := codec.Encode; enc != nil {
+			codec.Encode = func(ctx context.Context, response interface{}) (json.RawMessage, error) {
+				msg, err := enc(ctx, response)
+				return msg, encodeErrorKind(err)
+			}
+		}
+		ret[method] = codec
+	}
+	return ret
+}
@@ -0,0 +1,66 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"github.com/RangelReale/go-kit-typed/transport/http/jsonrpc"
+	gokitjsonrpctransport "github.com/go-kit/kit/transport/http/jsonrpc"
+)
+
+func TestErrorKindRoundTrip(t *testing.T) {
+	ecm := gokitjsonrpctransport.EndpointCodecMap{
+		"fail": gokitjsonrpctransport.EndpointCodec{
+			Endpoint: func(_ context.Context, request interface{}) (interface{}, error) {
+				return nil, errkind.New(errkind.Kind(request.(string)), "dang")
+			},
+			Decode: func(_ context.Context, msg json.RawMessage) (interface{}, error) {
+				var kind string
+				err := json.Unmarshal(msg, &kind)
+				return kind, err
+			},
+			Encode: func(context.Context, interface{}) (json.RawMessage, error) { return nil, nil },
+		},
+	}
+	server := httptest.NewServer(jsonrpc.NewServer[any, any](ecm))
+	defer server.Close()
+
+	client := jsonrpc.NewClient[any, any](mustParse(server.URL), "fail").Endpoint()
+	for _, kind := range []errkind.Kind{errkind.NotFound, errkind.InvalidArgument, errkind.Unavailable, errkind.Internal} {
+		_, err := client(context.Background(), string(kind))
+		if !errors.Is(err, kind) {
+			t.Errorf("want errors.Is %q, have %v", kind, err)
+		}
+		if want, have := "dang", err.Error(); want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+		var rpcErr gokitjsonrpctransport.Error
+		if !errors.As(err, &rpcErr) {
+			t.Fatalf("want jsonrpc.Error, have %T", err)
+		}
+		if want, have := errkind.JSONRPCCode(kind), rpcErr.Code; want != have {
+			t.Errorf("want %d, have %d", want, have)
+		}
+	}
+}
+
+func TestErrorKindPlainError(t *testing.T) {
+	ecm := gokitjsonrpctransport.EndpointCodecMap{
+		"fail": gokitjsonrpctransport.EndpointCodec{
+			Endpoint: func(context.Context, interface{}) (interface{}, error) { return nil, errors.New("dang") },
+			Decode:   func(context.Context, json.RawMessage) (interface{}, error) { return nil, nil },
+			Encode:   func(context.Context, interface{}) (json.RawMessage, error) { return nil, nil },
+		},
+	}
+	server := httptest.NewServer(jsonrpc.NewServer[any, any](ecm))
+	defer server.Close()
+
+	_, err := jsonrpc.NewClient[any, any](mustParse(server.URL), "fail").Endpoint()(context.Background(), nil)
+	if want, have := errkind.Internal, errkind.KindOf(err); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
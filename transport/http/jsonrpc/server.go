@@ -12,11 +12,15 @@ type Server[Req any, Resp any] struct {
 }
 
 // NewServer constructs a new server, which implements http.Server.
+//
+// Errors with an errkind kind returned by the endpoints and codecs, which
+// don't implement gokitjsonrpctransport.ErrorCoder, are sent with the JSON-RPC
+// code of their kind.
 func NewServer[Req any, Resp any](
 	ecm gokitjsonrpctransport.EndpointCodecMap,
 	options ...gokitjsonrpctransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokitjsonrpctransport.NewServer(ecm, options...)
+	server := gokitjsonrpctransport.NewServer(errorKindCodecs(ecm), options...)
 	return &Server[Req, Resp]{
 		server: server,
 	}
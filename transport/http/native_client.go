@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// NativeClient is an alternative to Client which implements the request
+// handling itself instead of wrapping a Go kit client, so requests and
+// responses are passed between the encoder, decoder and finalizers with their
+// static types, without being converted to interface{}.
+//
+// Its options have the same semantics as the Go kit client options accepted by
+// Client, and it has the same typed hooks.
+type NativeClient[Req any, Resp any] struct {
+	req        CreateRequestFunc[Req]
+	dec        DecodeResponseFunc[Resp]
+	options    clientOptions
+	after      []ClientAfterFunc[Resp]
+	finalizers []ClientFinalizerTypedFunc[Req, Resp]
+}
+
+// NewNativeClient constructs a usable NativeClient for a single remote method.
+func NewNativeClient[Req any, Resp any](method string, tgt *url.URL, enc EncodeRequestFunc[Req],
+	dec DecodeResponseFunc[Resp], options ...NativeClientOption) *NativeClient[Req, Resp] {
+	return NewNativeExplicitClient(makeCreateRequestFunc(method, tgt, enc), dec, options...)
+}
+
+// NewNativeExplicitClient is like NewNativeClient but uses a CreateRequestFunc
+// instead of a method, target URL, and EncodeRequestFunc, which allows for more
+// control over the outgoing HTTP request.
+func NewNativeExplicitClient[Req any, Resp any](req CreateRequestFunc[Req], dec DecodeResponseFunc[Resp],
+	options ...NativeClientOption) *NativeClient[Req, Resp] {
+	return &NativeClient[Req, Resp]{
+		req:     req,
+		dec:     dec,
+		options: newClientOptions(options),
+	}
+}
+
+// After adds functions which are executed after the response is successfully
+// decoded, and receive the HTTP response and the decoded response. They are
+// executed after the NativeClientAfter functions and the decoder, and must be
+// set before the endpoint is created.
+func (c *NativeClient[Req, Resp]) After(after ...ClientAfterFunc[Resp]) *NativeClient[Req, Resp] {
+	c.after = append(c.after, after...)
+	return c
+}
+
+// Finalizer adds functions which are executed at the end of every call, like
+// NativeClientFinalizer, and also receive the request, the decoded response and
+// the error of the call. They are executed after the NativeClientFinalizer
+// functions, and must be set before the endpoint is created.
+func (c *NativeClient[Req, Resp]) Finalizer(f ...ClientFinalizerTypedFunc[Req, Resp]) *NativeClient[Req, Resp] {
+	c.finalizers = append(c.finalizers, f...)
+	return c
+}
+
+// Endpoint returns a usable endpoint that calls the remote HTTP endpoint.
+func (c NativeClient[Req, Resp]) Endpoint() endpoint.Endpoint[Req, Resp] {
+	return func(ctx context.Context, request Req) (response Resp, err error) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		var resp *http.Response
+		if len(c.options.finalizer) > 0 || len(c.finalizers) > 0 {
+			defer func() {
+				if resp != nil {
+					ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseHeaders, resp.Header)
+					ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseSize, resp.ContentLength)
+				}
+				for _, f := range c.options.finalizer {
+					f(ctx, err)
+				}
+				for _, f := range c.finalizers {
+					f(ctx, request, response, err)
+				}
+			}()
+		}
+
+		req, err := c.req(ctx, request)
+		if err != nil {
+			cancel()
+			return response, err
+		}
+
+		for _, f := range c.options.before {
+			ctx = f(ctx, req)
+		}
+
+		resp, err = c.options.client.Do(req.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return response, err
+		}
+
+		// If the caller asked for a buffered stream, we don't cancel the
+		// context when the endpoint returns. Instead, we should call the
+		// cancel func when closing the response body.
+		if c.options.bufferedStream {
+			resp.Body = bodyWithCancel{ReadCloser: resp.Body, cancel: cancel}
+		} else {
+			defer resp.Body.Close()
+			defer cancel()
+		}
+
+		for _, f := range c.options.after {
+			ctx = f(ctx, resp)
+		}
+
+		if err = c.options.decodeError(ctx, resp); err != nil {
+			return response, err
+		}
+		if response, err = c.dec(ctx, resp); err != nil {
+			return response, err
+		}
+		for _, f := range c.after {
+			f(ctx, resp, response)
+		}
+		return response, nil
+	}
+}
+
+func makeCreateRequestFunc[Req any](method string, target *url.URL, enc EncodeRequestFunc[Req]) CreateRequestFunc[Req] {
+	return func(ctx context.Context, request Req) (*http.Request, error) {
+		req, err := http.NewRequest(method, target.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if err = enc(ctx, req, request); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+}
+
+// bodyWithCancel is a wrapper for an io.ReadCloser with also a cancel function
+// which is called when the Close is used.
+type bodyWithCancel struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (bwc bodyWithCancel) Close() error {
+	err := bwc.ReadCloser.Close()
+	bwc.cancel()
+	return err
+}
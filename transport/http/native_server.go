@@ -0,0 +1,191 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// NativeServer is an alternative to Server which implements the request
+// handling itself instead of wrapping a Go kit server, so requests and
+// responses are passed between the decoder, endpoint, hooks and encoder with
+// their static types, without being converted to interface{}.
+//
+// Its options have the same semantics as the Go kit server options accepted by
+// Server, and it has the same typed hooks.
+type NativeServer[Req any, Resp any] struct {
+	e       endpoint.Endpoint[Req, Resp]
+	dec     DecodeRequestFunc[Req]
+	enc     EncodeResponseFunc[Resp]
+	options serverOptions
+	hooks   serverHooks[Req, Resp]
+}
+
+// NewNativeServer constructs a new native server, which implements
+// http.Handler and wraps the provided endpoint.
+func NewNativeServer[Req any, Resp any](
+	e endpoint.Endpoint[Req, Resp],
+	dec DecodeRequestFunc[Req],
+	enc EncodeResponseFunc[Resp],
+	options ...NativeServerOption,
+) *NativeServer[Req, Resp] {
+	return &NativeServer[Req, Resp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		options: newServerOptions(options),
+	}
+}
+
+// After adds functions which are executed on the HTTP response writer after the
+// endpoint is invoked, like NativeServerAfter, and also receive the endpoint
+// response. They are executed after the NativeServerAfter functions. The typed
+// hooks must be set before the server handles requests.
+func (s *NativeServer[Req, Resp]) After(after ...ServerAfterFunc[Resp]) *NativeServer[Req, Resp] {
+	s.hooks.after = append(s.hooks.after, after...)
+	return s
+}
+
+// ErrorHandler sets a handler for non-terminal errors, like
+// NativeServerErrorHandler, which also receives the decoded request. It is
+// called before the NativeServerErrorHandler.
+func (s *NativeServer[Req, Resp]) ErrorHandler(h ServerErrorHandlerFunc[Req]) *NativeServer[Req, Resp] {
+	s.hooks.errorHandler = h
+	return s
+}
+
+// Finalizer adds functions which are executed at the end of every HTTP request,
+// like NativeServerFinalizer, and also receive the decoded request, the
+// endpoint response and the error of the request. They are executed after the
+// NativeServerFinalizer functions.
+func (s *NativeServer[Req, Resp]) Finalizer(f ...ServerFinalizerTypedFunc[Req, Resp]) *NativeServer[Req, Resp] {
+	s.hooks.finalizer = append(s.hooks.finalizer, f...)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s NativeServer[Req, Resp]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var (
+		request  Req
+		response Resp
+		err      error
+	)
+	if len(s.options.finalizer) > 0 || len(s.hooks.finalizer) > 0 {
+		iw := &interceptingWriter{ResponseWriter: w, code: http.StatusOK}
+		defer func() {
+			ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseHeaders, iw.Header())
+			ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseSize, iw.written)
+			for _, f := range s.options.finalizer {
+				f(ctx, iw.code, r)
+			}
+			for _, f := range s.hooks.finalizer {
+				f(ctx, iw.code, r, request, response, err)
+			}
+		}()
+		w = iw
+	}
+
+	for _, f := range s.options.before {
+		ctx = f(ctx, r)
+	}
+
+	request, err = s.dec(ctx, r)
+	if err != nil {
+		s.handleError(ctx, request, err, w)
+		return
+	}
+
+	response, err = s.e(ctx, request)
+	if err != nil {
+		s.handleError(ctx, request, err, w)
+		return
+	}
+
+	for _, f := range s.options.after {
+		ctx = f(ctx, w)
+	}
+	for _, f := range s.hooks.after {
+		ctx = f(ctx, w, response)
+	}
+
+	if err = s.enc(ctx, w, response); err != nil {
+		s.handleError(ctx, request, err, w)
+		return
+	}
+}
+
+func (s NativeServer[Req, Resp]) handleError(ctx context.Context, request Req, err error, w http.ResponseWriter) {
+	if s.hooks.errorHandler != nil {
+		s.hooks.errorHandler(ctx, request, err)
+	}
+	if s.options.errorHandler != nil {
+		s.options.errorHandler.Handle(ctx, err)
+	}
+	s.options.errorEncoder(ctx, err, w)
+}
+
+// interceptingWriter records the status code and the number of bytes written
+// to the response, for the finalizers.
+type interceptingWriter struct {
+	http.ResponseWriter
+	code    int
+	written int64
+}
+
+// WriteHeader may not be explicitly called, so care must be taken to
+// initialize w.code to its default value of http.StatusOK.
+func (w *interceptingWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *interceptingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher, if the wrapped writer supports it.
+func (w *interceptingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, if the wrapped writer supports it.
+func (w *interceptingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T does not implement http.Hijacker", w.ResponseWriter)
+	}
+	return h.Hijack()
+}
+
+// ReadFrom implements io.ReaderFrom, using the one of the wrapped writer if it
+// supports it.
+func (w *interceptingWriter) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		n   int64
+		err error
+	)
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	w.written += n
+	return n, err
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (w *interceptingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
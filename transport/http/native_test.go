@@ -0,0 +1,311 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+type nativeRequest struct {
+	A string `json:"a"`
+}
+
+type nativeResponse struct {
+	V string `json:"v"`
+}
+
+func nativeEndpoint(_ context.Context, req nativeRequest) (nativeResponse, error) {
+	if req.A == "" {
+		return nativeResponse{}, httptransport.NewStatusError(http.StatusBadRequest, errors.New("empty"))
+	}
+	return nativeResponse{V: strings.ToUpper(req.A)}, nil
+}
+
+func TestNativeServer(t *testing.T) {
+	var (
+		afterResp     nativeResponse
+		handlerReq    nativeRequest
+		handlerErr    error
+		finalizerCode int
+		finalizerErr  error
+		beforeCalled  bool
+	)
+	handler := httptransport.NewNativeServer(
+		nativeEndpoint,
+		httptransport.DecodeJSONRequest[nativeRequest],
+		httptransport.EncodeJSONResponse[nativeResponse],
+		httptransport.NativeServerBefore(func(ctx context.Context, _ *http.Request) context.Context {
+			beforeCalled = true
+			return ctx
+		}),
+	).After(func(ctx context.Context, _ http.ResponseWriter, resp nativeResponse) context.Context {
+		afterResp = resp
+		return ctx
+	}).ErrorHandler(func(_ context.Context, req nativeRequest, err error) {
+		handlerReq, handlerErr = req, err
+	}).Finalizer(func(_ context.Context, code int, _ *http.Request, _ nativeRequest, _ nativeResponse, err error) {
+		finalizerCode, finalizerErr = code, err
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"a":"abc"}`)))
+	if want, have := http.StatusOK, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := `{"v":"ABC"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if !beforeCalled {
+		t.Error("before not called")
+	}
+	if want, have := "ABC", afterResp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := http.StatusOK, finalizerCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if finalizerErr != nil {
+		t.Errorf("want nil error, have %v", finalizerErr)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{}`)))
+	if want, have := http.StatusBadRequest, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := http.StatusBadRequest, finalizerCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "empty", handlerErr.Error(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "", handlerReq.A; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if finalizerErr == nil {
+		t.Error("want finalizer error, have nil")
+	}
+}
+
+func TestNativeServerWriterInterfaces(t *testing.T) {
+	var (
+		finalizerCode int
+		done          = make(chan struct{})
+	)
+	handler := httptransport.NewNativeServer(
+		nativeEndpoint,
+		httptransport.DecodeJSONRequest[nativeRequest],
+		func(_ context.Context, w http.ResponseWriter, resp nativeResponse) error {
+			if _, ok := w.(io.ReaderFrom); !ok {
+				t.Error("writer does not implement io.ReaderFrom")
+			}
+			if _, ok := w.(interface{ Unwrap() http.ResponseWriter }); !ok {
+				t.Error("writer does not implement Unwrap")
+			}
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: " + strconv.Itoa(len(resp.V)) +
+				"\r\nConnection: close\r\n\r\n" + resp.V)
+			return rw.Flush()
+		},
+	).Finalizer(func(_ context.Context, code int, _ *http.Request, _ nativeRequest, _ nativeResponse, _ error) {
+		finalizerCode = code
+		close(done)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(`{"a":"abc"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if want, have := "ABC", string(body); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	<-done
+	if want, have := http.StatusOK, finalizerCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+func TestNativeClient(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewNativeServer(
+		nativeEndpoint,
+		httptransport.DecodeJSONRequest[nativeRequest],
+		httptransport.EncodeJSONResponse[nativeResponse],
+	))
+	defer server.Close()
+
+	var (
+		finalizerReq  nativeRequest
+		finalizerResp nativeResponse
+		finalizerErr  error
+		afterStatus   int
+		afterResp     nativeResponse
+	)
+	client := httptransport.NewNativeClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[nativeRequest],
+		httptransport.DecodeJSONResponse[nativeResponse],
+		httptransport.NativeClientAfter(func(ctx context.Context, r *http.Response) context.Context {
+			afterStatus = r.StatusCode
+			return ctx
+		}),
+		httptransport.ClientErrorResponseDecoder(func(_ context.Context, r *http.Response) error {
+			body, _ := ioutil.ReadAll(r.Body)
+			return errors.New(strings.TrimSpace(string(body)))
+		}),
+	).After(func(_ context.Context, _ *http.Response, resp nativeResponse) {
+		afterResp = resp
+	}).Finalizer(func(_ context.Context, req nativeRequest, resp nativeResponse, err error) {
+		finalizerReq, finalizerResp, finalizerErr = req, resp, err
+	})
+
+	resp, err := client.Endpoint()(context.Background(), nativeRequest{A: "xyz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "XYZ", resp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := http.StatusOK, afterStatus; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "XYZ", afterResp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "xyz", finalizerReq.A; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "XYZ", finalizerResp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	_, err = client.Endpoint()(context.Background(), nativeRequest{})
+	var respErr *httptransport.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("want ResponseError, have %v", err)
+	}
+	if want, have := http.StatusBadRequest, respErr.StatusCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := err, finalizerErr; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+type benchmarkHTTPClient struct{}
+
+func (benchmarkHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"v":"ABC"}`)),
+	}, nil
+}
+
+type benchmarkResponseWriter struct {
+	header http.Header
+}
+
+func (w *benchmarkResponseWriter) Header() http.Header         { return w.header }
+func (w *benchmarkResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *benchmarkResponseWriter) WriteHeader(int)             {}
+
+func benchmarkServerAfter(ctx context.Context, _ http.ResponseWriter, _ nativeResponse) context.Context {
+	return ctx
+}
+
+func benchmarkServerFinalizer(context.Context, int, *http.Request, nativeRequest, nativeResponse, error) {
+}
+
+func benchmarkServer(b *testing.B, handler http.Handler) {
+	r := httptest.NewRequest("POST", "/", nil)
+	w := &benchmarkResponseWriter{header: http.Header{}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		handler.ServeHTTP(w, r)
+	}
+}
+
+func benchmarkDecodeRequest(context.Context, *http.Request) (nativeRequest, error) {
+	return nativeRequest{A: "abc"}, nil
+}
+
+func benchmarkEncodeResponse(context.Context, http.ResponseWriter, nativeResponse) error {
+	return nil
+}
+
+func BenchmarkServer(b *testing.B) {
+	benchmarkServer(b, httptransport.NewServer(nativeEndpoint, benchmarkDecodeRequest, benchmarkEncodeResponse).
+		After(benchmarkServerAfter).
+		Finalizer(benchmarkServerFinalizer))
+}
+
+func BenchmarkNativeServer(b *testing.B) {
+	benchmarkServer(b, httptransport.NewNativeServer(nativeEndpoint, benchmarkDecodeRequest, benchmarkEncodeResponse).
+		After(benchmarkServerAfter).
+		Finalizer(benchmarkServerFinalizer))
+}
+
+func benchmarkNativeClientOptions() []httptransport.NativeClientOption {
+	return []httptransport.NativeClientOption{
+		httptransport.NativeSetClient(benchmarkHTTPClient{}),
+		httptransport.NativeClientFinalizer(benchmarkClientFinalizerUntyped),
+	}
+}
+
+func benchmarkClientFinalizerUntyped(context.Context, error) {}
+
+func benchmarkClientFinalizer(context.Context, nativeRequest, nativeResponse, error) {}
+
+func benchmarkEncodeRequest(context.Context, *http.Request, nativeRequest) error {
+	return nil
+}
+
+func benchmarkDecodeResponse(context.Context, *http.Response) (nativeResponse, error) {
+	return nativeResponse{V: "ABC"}, nil
+}
+
+func benchmarkClient(b *testing.B, e func(context.Context, nativeRequest) (nativeResponse, error)) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := e(ctx, nativeRequest{A: "abc"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClient(b *testing.B) {
+	client := httptransport.NewClient("POST", mustParse("http://localhost/"),
+		benchmarkEncodeRequest, benchmarkDecodeResponse,
+		gokithttptransport.SetClient(benchmarkHTTPClient{}),
+		gokithttptransport.ClientFinalizer(benchmarkClientFinalizerUntyped)).
+		Finalizer(benchmarkClientFinalizer)
+	benchmarkClient(b, client.Endpoint())
+}
+
+func BenchmarkNativeClient(b *testing.B) {
+	client := httptransport.NewNativeClient("POST", mustParse("http://localhost/"),
+		benchmarkEncodeRequest, benchmarkDecodeResponse, benchmarkNativeClientOptions()...).
+		Finalizer(benchmarkClientFinalizer)
+	benchmarkClient(b, client.Endpoint())
+}
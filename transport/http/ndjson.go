@@ -0,0 +1,171 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/errkind"
+)
+
+// NDJSONContentType is the content type of newline-delimited JSON streams.
+const NDJSONContentType = "application/x-ndjson"
+
+// NDJSONErrorTrailer is the HTTP trailer used to send errors which happen
+// after the stream started.
+const NDJSONErrorTrailer = "Stream-Error"
+
+// NDJSONServer wraps a streaming endpoint and implements http.Handler, writing
+// each response as a JSON value on its own line.
+//
+// Every line is flushed as soon as it is written, and send blocks until the
+// write completes, so a slow client slows down the endpoint instead of the
+// responses piling up in memory. The response headers are written with the
+// first line or heartbeat, so errors which happen before it are written by
+// the error encoder. Errors which happen later are sent in the Stream-Error
+// trailer. Heartbeats are sent as empty lines. The request context is canceled
+// when the client disconnects.
+//
+// The errkind kind of errors sent in the Stream-Error trailer is sent in the
+// Error-Kind trailer.
+type NDJSONServer[Req any, Resp any] struct {
+	e       endpoint.ServerStreamEndpoint[Req, Resp]
+	dec     DecodeRequestFunc[Req]
+	options streamServerOptions
+}
+
+// NewNDJSONServer constructs a new NDJSON server, which implements
+// http.Handler and wraps the provided streaming endpoint.
+func NewNDJSONServer[Req any, Resp any](
+	e endpoint.ServerStreamEndpoint[Req, Resp],
+	dec DecodeRequestFunc[Req],
+	options ...StreamServerOption,
+) *NDJSONServer[Req, Resp] {
+	return &NDJSONServer[Req, Resp]{
+		e:       e,
+		dec:     dec,
+		options: newStreamServerOptions(options),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s NDJSONServer[Req, Resp]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveStream(s.options, s.dec, w, r, func(ctx context.Context, w http.ResponseWriter, request Req) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fw := &flushWriter{w: w, start: func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", NDJSONContentType)
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Trailer", NDJSONErrorTrailer+", "+errkind.Header)
+			w.WriteHeader(http.StatusOK)
+		}}
+		stop := startHeartbeat(ctx, s.options.heartbeat, func() error {
+			return fw.writeString("\n")
+		})
+		err := s.e(ctx, request, func(response Resp) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			line, err := json.Marshal(response)
+			if err != nil {
+				return err
+			}
+			return fw.writeString(string(line) + "\n")
+		})
+		stop()
+
+		if err != nil {
+			s.options.handleError(ctx, err)
+			if !fw.isStarted() {
+				s.options.errorEncoder(ctx, err, w)
+				return
+			}
+			w.Header().Set(NDJSONErrorTrailer, sseSanitize(err.Error()))
+			w.Header().Set(errkind.Header, string(errkind.KindOf(err)))
+		}
+	})
+}
+
+// NDJSONClient wraps a URL and provides a method that implements
+// endpoint.ServerStreamEndpoint, decoding each line of the response body into
+// a response as it arrives.
+type NDJSONClient[Req any, Resp any] struct {
+	req     CreateRequestFunc[Req]
+	options streamClientOptions
+}
+
+// NewNDJSONClient constructs a usable NDJSONClient for a single remote method.
+// SSEReconnect is ignored.
+func NewNDJSONClient[Req any, Resp any](method string, tgt *url.URL, enc EncodeRequestFunc[Req],
+	options ...StreamClientOption) *NDJSONClient[Req, Resp] {
+	return &NDJSONClient[Req, Resp]{
+		req:     makeCreateRequestFunc(method, tgt, enc),
+		options: newStreamClientOptions(options),
+	}
+}
+
+// Endpoint returns a usable streaming endpoint that calls the remote NDJSON
+// endpoint, calling send for each received line. An error sent by the server
+// after the stream started is returned as a *StreamError. If send returns an
+// error, the connection is closed and the error is returned.
+func (c NDJSONClient[Req, Resp]) Endpoint() endpoint.ServerStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, request Req, send func(Resp) error) (err error) {
+		if len(c.options.finalizer) > 0 {
+			defer func() {
+				for _, f := range c.options.finalizer {
+					f(ctx, err)
+				}
+			}()
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		req, err := c.req(ctx, request)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", NDJSONContentType)
+
+		for _, f := range c.options.before {
+			ctx = f(ctx, req)
+		}
+
+		resp, err := c.options.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		for _, f := range c.options.after {
+			ctx = f(ctx, resp)
+		}
+
+		if err := c.options.checkResponse(ctx, resp); err != nil {
+			return err
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var response Resp
+			if err := dec.Decode(&response); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return err
+			}
+			if err := send(response); err != nil {
+				return err
+			}
+		}
+
+		if msg := resp.Trailer.Get(NDJSONErrorTrailer); msg != "" {
+			return &StreamError{Message: msg, Kind: errkind.Kind(resp.Trailer.Get(errkind.Header))}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+func TestNDJSONServerClient(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewNDJSONServer(countEndpoint, decodeSSERequest))
+	defer server.Close()
+
+	client := httptransport.NewNDJSONClient[sseRequest, sseResponse]("GET", mustParse(server.URL), encodeSSERequest)
+	ns, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []int{1, 2, 3}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNDJSONServerWireFormat(t *testing.T) {
+	handler := httptransport.NewNDJSONServer(countEndpoint, decodeSSERequest)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/?count=2", nil))
+
+	if want, have := httptransport.NDJSONContentType, rec.Header().Get("Content-Type"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "{\"n\":1}\n{\"n\":2}\n", rec.Body.String(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestNDJSONIncremental(t *testing.T) {
+	received := make(chan struct{})
+	server := httptest.NewServer(httptransport.NewNDJSONServer(
+		func(ctx context.Context, _ sseRequest, send func(sseResponse) error) error {
+			if err := send(sseResponse{N: 1}); err != nil {
+				return err
+			}
+			// the second response is only sent after the client received the
+			// first one, so the client must not wait for the whole body.
+			select {
+			case <-received:
+			case <-time.After(5 * time.Second):
+				return errors.New("first response not received")
+			}
+			return send(sseResponse{N: 2})
+		},
+		decodeSSERequest,
+		httptransport.StreamHeartbeat(time.Millisecond),
+	))
+	defer server.Close()
+
+	client := httptransport.NewNDJSONClient[sseRequest, sseResponse]("GET", mustParse(server.URL), encodeSSERequest)
+	var ns []int
+	err := client.Endpoint()(context.Background(), sseRequest{}, func(resp sseResponse) error {
+		ns = append(ns, resp.N)
+		if resp.N == 1 {
+			time.Sleep(10 * time.Millisecond) // let some heartbeats through
+			close(received)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []int{1, 2}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNDJSONErrors(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewNDJSONServer(
+		func(ctx context.Context, req sseRequest, send func(sseResponse) error) error {
+			if req.Count < 0 {
+				return httptransport.NewStatusError(http.StatusBadRequest, errors.New("negative count"))
+			}
+			if err := send(sseResponse{N: 1}); err != nil {
+				return err
+			}
+			return errors.New("failed after start")
+		},
+		decodeSSERequest,
+	))
+	defer server.Close()
+
+	client := httptransport.NewNDJSONClient[sseRequest, sseResponse]("GET", mustParse(server.URL), encodeSSERequest)
+
+	_, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: -1})
+	var respErr *httptransport.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("want ResponseError, have %v", err)
+	}
+	if want, have := http.StatusBadRequest, respErr.StatusCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	ns, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: 1})
+	var streamErr *httptransport.StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("want StreamError, have %v", err)
+	}
+	if want, have := "failed after start", streamErr.Message; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := []int{1}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNDJSONCancellation(t *testing.T) {
+	canceled := make(chan error, 1)
+	server := httptest.NewServer(httptransport.NewNDJSONServer(
+		func(ctx context.Context, _ sseRequest, send func(sseResponse) error) error {
+			for i := 1; ; i++ {
+				if err := send(sseResponse{N: i}); err != nil {
+					canceled <- err
+					return err
+				}
+				time.Sleep(time.Millisecond)
+			}
+		},
+		decodeSSERequest,
+	))
+	defer server.Close()
+
+	client := httptransport.NewNDJSONClient[sseRequest, sseResponse]("GET", mustParse(server.URL), encodeSSERequest)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := client.Endpoint()(ctx, sseRequest{}, func(resp sseResponse) error {
+		if resp.N == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want %v, have %v", context.Canceled, err)
+	}
+
+	select {
+	case err := <-canceled:
+		if err == nil {
+			t.Error("want send error, have nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server endpoint was not canceled")
+	}
+}
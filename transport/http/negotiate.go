@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// NegotiateDecodeRequest returns a DecodeRequestFunc which decodes the request
+// body using the codec matching the request Content-Type. Requests without a
+// Content-Type are decoded with the first codec, and requests with an
+// unsupported one return a StatusError with 415 Unsupported Media Type.
+func NegotiateDecodeRequest[Req any](codecs ...codec.Codec[Req]) DecodeRequestFunc[Req] {
+	return func(ctx context.Context, r *http.Request) (Req, error) {
+		c, ok := findContentTypeCodec(codecs, r.Header.Get("Content-Type"))
+		if !ok {
+			var ret Req
+			return ret, NewStatusError(http.StatusUnsupportedMediaType,
+				fmt.Errorf("unsupported content type '%s'", r.Header.Get("Content-Type")))
+		}
+		return DecodeRequestFuncFromCodec(c)(ctx, r)
+	}
+}
+
+// NegotiateEncodeResponse returns an EncodeResponseFunc which encodes the
+// response using the codec which best matches the request Accept header,
+// honoring quality values. When more than one codec is equally acceptable, the
+// first one in the list is used. If no codec is acceptable, a StatusError with
+// 406 Not Acceptable is returned.
+//
+// The Accept header is read from the context, where the servers of this
+// package store it before the request is decoded. As the encoder only runs
+// after the endpoint, use Negotiate to reject unacceptable requests before the
+// endpoint is called.
+func NegotiateEncodeResponse[Resp any](codecs ...codec.Codec[Resp]) EncodeResponseFunc[Resp] {
+	return func(ctx context.Context, w http.ResponseWriter, response Resp) error {
+		accept, _ := ctx.Value(gokithttptransport.ContextKeyRequestAccept).(string)
+		c, ok := findAcceptCodec(codecs, accept)
+		if !ok {
+			return notAcceptableError(accept)
+		}
+		w.Header().Add("Vary", "Accept")
+		return writeCodecResponse(w, c, response)
+	}
+}
+
+// Negotiate returns the decoder and encoder of a server which negotiates the
+// request and response codecs, like NegotiateDecodeRequest and
+// NegotiateEncodeResponse. The decoder also checks the request Accept header,
+// so requests for which no response codec is acceptable are rejected with 406
+// Not Acceptable before the endpoint is called.
+func Negotiate[Req any, Resp any](requestCodecs []codec.Codec[Req],
+	responseCodecs ...codec.Codec[Resp]) (DecodeRequestFunc[Req], EncodeResponseFunc[Resp]) {
+	dec := NegotiateDecodeRequest(requestCodecs...)
+	return func(ctx context.Context, r *http.Request) (Req, error) {
+		if accept := r.Header.Get("Accept"); !acceptsAnyCodec(responseCodecs, accept) {
+			var ret Req
+			return ret, notAcceptableError(accept)
+		}
+		return dec(ctx, r)
+	}, NegotiateEncodeResponse(responseCodecs...)
+}
+
+func notAcceptableError(accept string) error {
+	return NewStatusError(http.StatusNotAcceptable, fmt.Errorf("no acceptable content type for '%s'", accept))
+}
+
+// populateRequestAccept stores the request Accept header in the context, like
+// gokithttptransport.PopulateRequestContext, for NegotiateEncodeResponse.
+func populateRequestAccept(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, gokithttptransport.ContextKeyRequestAccept, r.Header.Get("Accept"))
+}
+
+// NegotiateEncodeRequest returns an EncodeRequestFunc which encodes the request
+// using the passed codec, and advertises the content types of the response
+// codecs in the Accept header, in order of preference.
+func NegotiateEncodeRequest[Req any, Resp any](c codec.Codec[Req],
+	responseCodecs ...codec.Codec[Resp]) EncodeRequestFunc[Req] {
+	accept := acceptHeader(responseCodecs)
+	enc := EncodeRequestFuncFromCodec(c)
+	return func(ctx context.Context, r *http.Request, request Req) error {
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		return enc(ctx, r, request)
+	}
+}
+
+// NegotiateDecodeResponse returns a DecodeResponseFunc which decodes the
+// response body using the codec matching the response Content-Type. Responses
+// without a Content-Type are decoded with the first codec.
+func NegotiateDecodeResponse[Resp any](codecs ...codec.Codec[Resp]) DecodeResponseFunc[Resp] {
+	return func(ctx context.Context, r *http.Response) (Resp, error) {
+		c, ok := findContentTypeCodec(codecs, r.Header.Get("Content-Type"))
+		if !ok {
+			var ret Resp
+			_, _ = ioutil.ReadAll(r.Body)
+			return ret, fmt.Errorf("unsupported response content type '%s'", r.Header.Get("Content-Type"))
+		}
+		return DecodeResponseFuncFromCodec(c)(ctx, r)
+	}
+}
+
+// acceptHeader returns an Accept header listing the content types of the
+// codecs in order of preference, with weights decreasing by 0.001 down to the
+// minimum of 0.001.
+func acceptHeader[T any](codecs []codec.Codec[T]) string {
+	var types []string
+	for _, c := range codecs {
+		mediaType := baseMediaType(c.ContentType())
+		if containsString(types, mediaType) {
+			continue
+		}
+		types = append(types, mediaType)
+	}
+	for i := 1; i < len(types); i++ {
+		q := 1000 - i
+		if q < 1 {
+			q = 1
+		}
+		types[i] = fmt.Sprintf("%s;q=0.%03d", types[i], q)
+	}
+	return strings.Join(types, ", ")
+}
+
+func findContentTypeCodec[T any](codecs []codec.Codec[T], contentType string) (codec.Codec[T], bool) {
+	if len(codecs) == 0 {
+		return nil, false
+	}
+	if contentType == "" {
+		return codecs[0], true
+	}
+	mediaType := baseMediaType(contentType)
+	for _, c := range codecs {
+		if baseMediaType(c.ContentType()) == mediaType {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func findAcceptCodec[T any](codecs []codec.Codec[T], accept string) (codec.Codec[T], bool) {
+	if len(codecs) == 0 {
+		return nil, false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return codecs[0], true
+	}
+	ranges := parseAccept(accept)
+	var (
+		best  codec.Codec[T]
+		bestQ float64
+	)
+	for _, c := range codecs {
+		if q := acceptQuality(ranges, baseMediaType(c.ContentType())); q > bestQ {
+			best, bestQ = c, q
+		}
+	}
+	return best, best != nil
+}
+
+func acceptsAnyCodec[T any](codecs []codec.Codec[T], accept string) bool {
+	_, ok := findAcceptCodec(codecs, accept)
+	return ok
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept parses an Accept header, returning the media ranges sorted from
+// the most to the least specific.
+func parseAccept(accept string) []acceptRange {
+	var ret []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if qv, err := strconv.ParseFloat(qs, 64); err == nil && qv >= 0 && qv <= 1 {
+				q = qv
+			}
+		}
+		ret = append(ret, acceptRange{mediaType: mediaType, q: q})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return mediaRangeSpecificity(ret[i].mediaType) > mediaRangeSpecificity(ret[j].mediaType)
+	})
+	return ret
+}
+
+// acceptQuality returns the quality of the most specific media range matching
+// the media type.
+func acceptQuality(ranges []acceptRange, mediaType string) float64 {
+	for _, r := range ranges {
+		if mediaRangeMatches(r.mediaType, mediaType) {
+			return r.q
+		}
+	}
+	return 0
+}
+
+func mediaRangeSpecificity(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func mediaRangeMatches(mediaRange string, mediaType string) bool {
+	switch {
+	case mediaRange == "*/*":
+		return true
+	case strings.HasSuffix(mediaRange, "/*"):
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*"))
+	default:
+		return mediaRange == mediaType
+	}
+}
+
+func baseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
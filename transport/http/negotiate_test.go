@@ -0,0 +1,196 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	"github.com/RangelReale/go-kit-typed/transport/grpc/_grpc_test/pb"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+func newNegotiateServer() *httptransport.Server[*pb.TestRequest, *pb.TestResponse] {
+	dec, enc := httptransport.Negotiate(
+		[]codec.Codec[*pb.TestRequest]{codec.Proto[*pb.TestRequest](), codec.JSON[*pb.TestRequest]()},
+		codec.JSON[*pb.TestResponse](), codec.Proto[*pb.TestResponse]())
+	return httptransport.NewServer(
+		func(_ context.Context, req *pb.TestRequest) (*pb.TestResponse, error) {
+			return &pb.TestResponse{V: strings.Repeat(req.A, int(req.B))}, nil
+		},
+		dec,
+		enc,
+	)
+}
+
+func TestNegotiateServer(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		accept      string
+		contentType string
+		wantStatus  int
+		wantType    string
+	}{
+		{"no accept", "", "application/json", http.StatusOK, "application/json; charset=utf-8"},
+		{"wildcard", "*/*", "application/json", http.StatusOK, "application/json; charset=utf-8"},
+		{"protobuf", "application/x-protobuf", "application/json", http.StatusOK, "application/x-protobuf"},
+		{"quality", "application/json;q=0.5, application/x-protobuf", "application/json", http.StatusOK, "application/x-protobuf"},
+		{"specific over wildcard", "application/*;q=0.9, application/json;q=0.1", "application/json", http.StatusOK, "application/x-protobuf"},
+		{"browser", "text/html,application/xhtml+xml,*/*;q=0.8", "application/json", http.StatusOK, "application/json; charset=utf-8"},
+		{"excluded", "application/json;q=0, */*", "application/json", http.StatusOK, "application/x-protobuf"},
+		{"not acceptable", "text/html", "application/json", http.StatusNotAcceptable, ""},
+		{"unsupported media type", "", "text/plain", http.StatusUnsupportedMediaType, ""},
+		{"no content type uses first codec", "", "", http.StatusBadRequest, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(`{"a":"ab","b":2}`))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+			rec := httptest.NewRecorder()
+			newNegotiateServer().ServeHTTP(rec, req)
+			if want, have := test.wantStatus, rec.Code; want != have {
+				t.Fatalf("want %d, have %d (%s)", want, have, rec.Body.String())
+			}
+			if test.wantStatus != http.StatusOK {
+				return
+			}
+			if want, have := test.wantType, rec.Header().Get("Content-Type"); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+			if want, have := "Accept", rec.Header().Get("Vary"); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+		})
+	}
+}
+
+func TestNegotiateRejectsBeforeEndpoint(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		dec    httptransport.DecodeRequestFunc[*pb.TestRequest]
+		enc    httptransport.EncodeResponseFunc[*pb.TestResponse]
+		called bool
+	}{
+		{"negotiate", nil, nil, false},
+		{"encoder only",
+			httptransport.NegotiateDecodeRequest(codec.JSON[*pb.TestRequest]()),
+			httptransport.NegotiateEncodeResponse(codec.JSON[*pb.TestResponse]()), true},
+	} {
+		dec, enc := test.dec, test.enc
+		if dec == nil {
+			dec, enc = httptransport.Negotiate([]codec.Codec[*pb.TestRequest]{codec.JSON[*pb.TestRequest]()},
+				codec.JSON[*pb.TestResponse]())
+		}
+		var called bool
+		server := httptransport.NewServer(
+			func(context.Context, *pb.TestRequest) (*pb.TestResponse, error) {
+				called = true
+				return &pb.TestResponse{}, nil
+			},
+			dec,
+			enc,
+		)
+
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+		req.Header.Set("Accept", "text/html")
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		if want, have := http.StatusNotAcceptable, rec.Code; want != have {
+			t.Errorf("%s: want %d, have %d", test.name, want, have)
+		}
+		if want, have := test.called, called; want != have {
+			t.Errorf("%s: want endpoint called %v, have %v", test.name, want, have)
+		}
+	}
+}
+
+func TestNegotiateServerClient(t *testing.T) {
+	var accept string
+	handler := newNegotiateServer()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.NegotiateEncodeRequest[*pb.TestRequest, *pb.TestResponse](codec.Proto[*pb.TestRequest](),
+			codec.Proto[*pb.TestResponse](), codec.JSON[*pb.TestResponse]()),
+		httptransport.NegotiateDecodeResponse(codec.Proto[*pb.TestResponse](), codec.JSON[*pb.TestResponse]()),
+	)
+
+	resp, err := client.Endpoint()(context.Background(), &pb.TestRequest{A: "ab", B: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "abab", resp.V; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "application/x-protobuf, application/json;q=0.999", accept; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+type contentTypeCodec struct {
+	codec.Codec[*pb.TestResponse]
+	contentType string
+}
+
+func (c contentTypeCodec) ContentType() string {
+	return c.contentType
+}
+
+func TestNegotiateEncodeRequestWeights(t *testing.T) {
+	var codecs []codec.Codec[*pb.TestResponse]
+	for i := 0; i < 12; i++ {
+		codecs = append(codecs, contentTypeCodec{
+			Codec:       codec.JSON[*pb.TestResponse](),
+			contentType: fmt.Sprintf("application/x-test-%d", i),
+		})
+	}
+	enc := httptransport.NegotiateEncodeRequest(codec.JSON[*pb.TestRequest](), codecs...)
+	req := httptest.NewRequest("POST", "/", nil)
+	if err := enc(context.Background(), req, &pb.TestRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	types := strings.Split(req.Header.Get("Accept"), ", ")
+	if want, have := 12, len(types); want != have {
+		t.Fatalf("want %d, have %d", want, have)
+	}
+	if want, have := "application/x-test-0", types[0]; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	for i := 1; i < len(types); i++ {
+		if want, have := fmt.Sprintf("application/x-test-%d;q=0.%03d", i, 1000-i), types[i]; want != have {
+			t.Errorf("want %s, have %s", want, have)
+		}
+	}
+}
+
+func TestNegotiateDecodeResponseUnsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeRequestFuncFromCodec(codec.JSON[*pb.TestRequest]()),
+		httptransport.NegotiateDecodeResponse(codec.Proto[*pb.TestResponse](), codec.JSON[*pb.TestResponse]()),
+	)
+
+	if _, err := client.Endpoint()(context.Background(), &pb.TestRequest{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,310 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// ProblemContentType is the content type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeInvalidParams is the problem type used for BindError.
+const ProblemTypeInvalidParams = "invalid-params"
+
+// Problem is a RFC 7807 problem details object. It implements error, so it can
+// be returned from endpoints and decoders, and is the error returned to
+// clients by DecodeProblemResponse.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. When empty,
+	// "about:blank" is assumed.
+	Type string
+	// Title is a short, human-readable summary of the problem type.
+	Title string
+	// Status is the HTTP status code.
+	Status int
+	// Detail is a human-readable explanation specific to this occurrence of
+	// the problem.
+	Detail string
+	// Instance is a URI reference that identifies the specific occurrence of
+	// the problem.
+	Instance string
+	// Extensions are additional members, serialized at the top level of the
+	// JSON object.
+	Extensions map[string]interface{}
+}
+
+// Problemer is checked by the problem error encoders. If an error implements
+// Problemer, the returned problem is used as the base of the response.
+type Problemer interface {
+	Problem() *Problem
+}
+
+// Error implements error.
+func (p *Problem) Error() string {
+	title := p.Title
+	if title == "" {
+		title = http.StatusText(p.Status)
+	}
+	if p.Detail == "" {
+		return title
+	}
+	if title == "" {
+		return p.Detail
+	}
+	return fmt.Sprintf("%s: %s", title, p.Detail)
+}
+
+// StatusCode implements gokithttptransport.StatusCoder.
+func (p *Problem) StatusCode() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return p.Status
+}
+
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// MarshalJSON implements json.Marshaler.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+len(problemMembers))
+	for k, v := range p.Extensions {
+		if !containsString(problemMembers, k) {
+			m[k] = v
+		}
+	}
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = Problem{}
+	for k, v := range m {
+		var err error
+		switch k {
+		case "type":
+			err = json.Unmarshal(v, &p.Type)
+		case "title":
+			err = json.Unmarshal(v, &p.Title)
+		case "status":
+			err = json.Unmarshal(v, &p.Status)
+		case "detail":
+			err = json.Unmarshal(v, &p.Detail)
+		case "instance":
+			err = json.Unmarshal(v, &p.Instance)
+		default:
+			var ext interface{}
+			if err = json.Unmarshal(v, &ext); err == nil {
+				if p.Extensions == nil {
+					p.Extensions = map[string]interface{}{}
+				}
+				p.Extensions[k] = ext
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("problem member '%s': %w", k, err)
+		}
+	}
+	return nil
+}
+
+// ProblemOption sets an optional parameter for MakeProblemErrorEncoder.
+type ProblemOption func(*problemOptions)
+
+type problemOptions struct {
+	hideInternalDetail bool
+	instance           func(ctx context.Context) string
+}
+
+// ProblemHideInternalDetail omits the detail of problems with a 5xx status
+// which were not built from a Problem or Problemer, to avoid leaking internal
+// error messages.
+func ProblemHideInternalDetail() ProblemOption {
+	return func(o *problemOptions) { o.hideInternalDetail = true }
+}
+
+// ProblemInstance sets the function which returns the instance member of
+// problems which do not have one. By default, the request URI is used when
+// the server is configured with gokithttptransport.PopulateRequestContext.
+func ProblemInstance(f func(ctx context.Context) string) ProblemOption {
+	return func(o *problemOptions) { o.instance = f }
+}
+
+// ProblemErrorEncoder is a gokithttptransport.ErrorEncoder which writes the
+// error as an application/problem+json response. It is MakeProblemErrorEncoder
+// with the default options.
+func ProblemErrorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	defaultProblemErrorEncoder(ctx, err, w)
+}
+
+var defaultProblemErrorEncoder = MakeProblemErrorEncoder()
+
+// MakeProblemErrorEncoder returns a gokithttptransport.ErrorEncoder which
+// writes errors as application/problem+json responses.
+//
+// Errors which are, or wrap, a *Problem or a Problemer are written as is. For
+// other errors the status is taken from StatusCoder, or from the errkind kind
+// (500 by default), the title is the status text and the detail the error
+// message. If the error implements Headerer, the headers are added to the
+// response. The kind of errors with a kind other than errkind.Unknown is sent
+// in the Error-Kind header. A BindError is written with
+// the "invalid-params" type and an "invalid-params" extension listing each
+// failed field.
+func MakeProblemErrorEncoder(options ...ProblemOption) gokithttptransport.ErrorEncoder {
+	opts := problemOptions{
+		instance: func(ctx context.Context) string {
+			uri, _ := ctx.Value(gokithttptransport.ContextKeyRequestURI).(string)
+			return uri
+		},
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		problem := errorProblem(err, opts)
+		if problem.Instance == "" && opts.instance != nil {
+			problem.Instance = opts.instance(ctx)
+		}
+		body, merr := json.Marshal(problem)
+		if merr != nil {
+			body = []byte(`{"title":"Internal Server Error","status":500}`)
+			problem.Status = http.StatusInternalServerError
+		}
+
+		var headerer gokithttptransport.Headerer
+		if errors.As(err, &headerer) {
+			for k, values := range headerer.Headers() {
+				for _, v := range values {
+					w.Header().Add(k, v)
+				}
+			}
+		}
+		if kind := errkind.KindOf(err); kind != errkind.Unknown {
+			w.Header().Set(errkind.Header, string(kind))
+		}
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(problem.StatusCode())
+		_, _ = w.Write(body)
+	}
+}
+
+func errorProblem(err error, opts problemOptions) Problem {
+	var problem *Problem
+	if errors.As(err, &problem) {
+		ret := *problem
+		ret.Status = problem.StatusCode()
+		return ret
+	}
+	var problemer Problemer
+	if errors.As(err, &problemer) {
+		if p := problemer.Problem(); p != nil {
+			ret := *p
+			ret.Status = p.StatusCode()
+			return ret
+		}
+	}
+
+	status := http.StatusInternalServerError
+	if kind := errkind.KindOf(err); kind != errkind.Unknown {
+		status = errkind.HTTPStatus(kind)
+	}
+	var sc gokithttptransport.StatusCoder
+	if errors.As(err, &sc) {
+		status = sc.StatusCode()
+	}
+	ret := Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		params := make([]map[string]interface{}, 0, len(bindErr.Fields))
+		for _, f := range bindErr.Fields {
+			params = append(params, map[string]interface{}{
+				"name":   f.Name,
+				"source": f.Source,
+				"reason": f.Err.Error(),
+			})
+		}
+		ret.Type = ProblemTypeInvalidParams
+		ret.Title = "Invalid request parameters"
+		ret.Extensions = map[string]interface{}{ProblemTypeInvalidParams: params}
+	}
+
+	if opts.hideInternalDetail && status >= 500 {
+		ret.Detail = ""
+	}
+	return ret
+}
+
+// DecodeProblem decodes a non-2xx response as a *Problem. Responses with the
+// application/problem+json content type are decoded from the body; for any
+// other response, a Problem with the status, its text as title and the body as
+// detail is returned.
+func DecodeProblem(r *http.Response) (*Problem, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == ProblemContentType {
+		problem := &Problem{}
+		if err := json.Unmarshal(body, problem); err != nil {
+			return nil, err
+		}
+		if problem.Status == 0 {
+			problem.Status = r.StatusCode
+		}
+		return problem, nil
+	}
+	return &Problem{
+		Title:  http.StatusText(r.StatusCode),
+		Status: r.StatusCode,
+		Detail: string(body),
+	}, nil
+}
+
+// DecodeProblemResponse returns a DecodeResponseFunc which returns the
+// *Problem decoded by DecodeProblem as the error for non-2xx responses, and
+// calls next for all other responses.
+func DecodeProblemResponse[Resp any](next DecodeResponseFunc[Resp]) DecodeResponseFunc[Resp] {
+	return func(ctx context.Context, r *http.Response) (Resp, error) {
+		if r.StatusCode < 200 || r.StatusCode > 299 {
+			var ret Resp
+			problem, err := DecodeProblem(r)
+			if err != nil {
+				return ret, err
+			}
+			return ret, problem
+		}
+		return next(ctx, r)
+	}
+}
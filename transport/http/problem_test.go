@@ -0,0 +1,153 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+type problemRequest struct {
+	ID string `json:"id"`
+}
+
+type problemHeaderError struct{}
+
+func (problemHeaderError) Error() string        { return "rate limited" }
+func (problemHeaderError) StatusCode() int      { return http.StatusTooManyRequests }
+func (problemHeaderError) Headers() http.Header { return http.Header{"Retry-After": []string{"10"}} }
+
+func TestProblemErrorEncoder(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		err        error
+		options    []httptransport.ProblemOption
+		wantStatus int
+		wantBody   map[string]interface{}
+	}{
+		{
+			name:       "plain error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]interface{}{"title": "Internal Server Error", "status": 500.0, "detail": "boom", "instance": "/things/1"},
+		},
+		{
+			name:       "hide internal detail",
+			err:        errors.New("boom"),
+			options:    []httptransport.ProblemOption{httptransport.ProblemHideInternalDetail()},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]interface{}{"title": "Internal Server Error", "status": 500.0, "instance": "/things/1"},
+		},
+		{
+			name:       "status error",
+			err:        httptransport.NewStatusError(http.StatusNotFound, errors.New("no such thing")),
+			wantStatus: http.StatusNotFound,
+			wantBody:   map[string]interface{}{"title": "Not Found", "status": 404.0, "detail": "no such thing", "instance": "/things/1"},
+		},
+		{
+			name: "problem",
+			err: &httptransport.Problem{Type: "https://example.com/out-of-credit", Title: "Out of credit", Status: http.StatusForbidden,
+				Instance: "/account/1", Extensions: map[string]interface{}{"balance": 30}},
+			wantStatus: http.StatusForbidden,
+			wantBody: map[string]interface{}{"type": "https://example.com/out-of-credit", "title": "Out of credit", "status": 403.0,
+				"instance": "/account/1", "balance": 30.0},
+		},
+		{
+			name: "bind error",
+			err: &httptransport.BindError{Fields: []httptransport.FieldError{
+				{Field: "Page", Source: "query", Name: "page", Value: "x", Err: errors.New("invalid syntax")},
+			}},
+			options:    []httptransport.ProblemOption{httptransport.ProblemInstance(func(context.Context) string { return "" })},
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]interface{}{"type": "invalid-params", "title": "Invalid request parameters", "status": 400.0,
+				"detail":         "invalid request: query 'page': invalid value 'x': invalid syntax",
+				"invalid-params": []interface{}{map[string]interface{}{"name": "page", "source": "query", "reason": "invalid syntax"}}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), gokithttptransport.ContextKeyRequestURI, "/things/1")
+			rec := httptest.NewRecorder()
+			httptransport.MakeProblemErrorEncoder(test.options...)(ctx, test.err, rec)
+
+			if want, have := test.wantStatus, rec.Code; want != have {
+				t.Errorf("want %d, have %d", want, have)
+			}
+			if want, have := httptransport.ProblemContentType, rec.Header().Get("Content-Type"); want != have {
+				t.Errorf("want %s, have %s", want, have)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if want, have := test.wantBody, body; !reflect.DeepEqual(want, have) {
+				t.Errorf("want %v, have %v", want, have)
+			}
+		})
+	}
+}
+
+func TestProblemErrorEncoderHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	httptransport.ProblemErrorEncoder(context.Background(), problemHeaderError{}, rec)
+	if want, have := http.StatusTooManyRequests, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "10", rec.Header().Get("Retry-After"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestProblemServerClient(t *testing.T) {
+	handler := httptransport.NewServer(
+		func(context.Context, problemRequest) (problemRequest, error) {
+			return problemRequest{}, &httptransport.Problem{Title: "Out of credit", Status: http.StatusForbidden,
+				Detail: "balance is 30", Extensions: map[string]interface{}{"balance": 30}}
+		},
+		httptransport.DecodeJSONRequest[problemRequest],
+		httptransport.EncodeJSONResponse[problemRequest],
+		gokithttptransport.ServerErrorEncoder(httptransport.ProblemErrorEncoder),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := httptransport.NewClient(
+		"POST",
+		mustParse(server.URL),
+		httptransport.EncodeJSONRequest[problemRequest],
+		httptransport.DecodeProblemResponse(httptransport.DecodeJSONResponse[problemRequest]),
+	)
+	_, err := client.Endpoint()(context.Background(), problemRequest{})
+
+	var problem *httptransport.Problem
+	if !errors.As(err, &problem) {
+		t.Fatalf("want Problem, have %v", err)
+	}
+	if want, have := http.StatusForbidden, problem.Status; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "Out of credit: balance is 30", problem.Error(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := 30.0, problem.Extensions["balance"]; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestDecodeProblemNotProblemJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	http.Error(rec, "gateway down", http.StatusBadGateway)
+	problem, err := httptransport.DecodeProblem(rec.Result())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &httptransport.Problem{Title: "Bad Gateway", Status: http.StatusBadGateway, Detail: "gateway down\n"}
+	if !reflect.DeepEqual(want, problem) {
+		t.Errorf("want %+v, have %+v", want, problem)
+	}
+}
@@ -0,0 +1,319 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// Cookier is checked by ReflectResponse. If a response implements Cookier,
+// the returned cookies are set on the response.
+type Cookier interface {
+	Cookies() []*http.Cookie
+}
+
+// ReflectResponse returns an EncodeResponseFunc which serializes the response
+// as a JSON object, after applying its status code, headers and cookies.
+//
+// These can be declared by implementing StatusCoder, Headerer and Cookier, or
+// with field tags on a struct (or pointer to struct) response:
+//
+//	type createProfileResponse struct {
+//	    Status   int          `http:"status"`
+//	    Location string       `header:"Location"`
+//	    Session  *http.Cookie `cookie:"session"`
+//	    ID       string       `json:"id"`
+//	}
+//
+// The response is marshaled as is, so json.Marshaler implementations are
+// honored, and the keys of the tagged fields are removed from the resulting
+// JSON object. Zero values are not applied, so a zero status means 200 OK.
+// Header fields may be strings, string slices, booleans, numbers, time.Time
+// (formatted with http.TimeFormat) or encoding.TextMarshaler implementations;
+// cookie fields may be http.Cookie, *http.Cookie or a string holding the
+// cookie value. Only the fields of the response type itself are inspected, not
+// the ones of embedded structs.
+//
+// ReflectResponse panics if the tagged fields have unsupported types.
+func ReflectResponse[Resp any]() EncodeResponseFunc[Resp] {
+	var resp Resp
+	info, err := newReflectInfo(reflect.TypeOf(&resp).Elem())
+	if err != nil {
+		panic(fmt.Sprintf("ReflectResponse: %s", err))
+	}
+
+	return func(_ context.Context, w http.ResponseWriter, response Resp) error {
+		body := interface{}(response)
+		rv := reflect.ValueOf(&response).Elem()
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		if info != nil && rv.IsValid() && len(info.bodyKeys) > 0 {
+			if data, err = removeJSONKeys(data, info.bodyKeys); err != nil {
+				return err
+			}
+		}
+
+		code := http.StatusOK
+		if headerer, ok := body.(gokithttptransport.Headerer); ok {
+			for k, values := range headerer.Headers() {
+				for _, v := range values {
+					w.Header().Add(k, v)
+				}
+			}
+		}
+		if cookier, ok := body.(Cookier); ok {
+			for _, c := range cookier.Cookies() {
+				http.SetCookie(w, c)
+			}
+		}
+		if sc, ok := body.(gokithttptransport.StatusCoder); ok {
+			code = sc.StatusCode()
+		}
+		if info != nil && rv.IsValid() {
+			if sc := info.apply(w, rv); sc != 0 {
+				code = sc
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(code)
+		if code == http.StatusNoContent {
+			return nil
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+}
+
+// reflectInfo holds the tagged fields of a response struct type, and their
+// keys in the JSON body.
+type reflectInfo struct {
+	status   []int
+	headers  []reflectField
+	cookies  []reflectField
+	bodyKeys map[string]bool
+}
+
+type reflectField struct {
+	index []int
+	name  string
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func newReflectInfo(t reflect.Type) (*reflectInfo, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	info := &reflectInfo{bodyKeys: map[string]bool{}}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if tag, ok := sf.Tag.Lookup("http"); ok && tag == "status" {
+			if !isIntKind(sf.Type.Kind()) || sf.PkgPath != "" {
+				return nil, fmt.Errorf("type %s: status field %s must be an exported integer", t, sf.Name)
+			}
+			info.status = sf.Index
+		} else if name, ok := sf.Tag.Lookup("header"); ok && name != "-" {
+			if !isHeaderType(sf.Type) || sf.PkgPath != "" {
+				return nil, fmt.Errorf("type %s: header field %s has unsupported type %s", t, sf.Name, sf.Type)
+			}
+			info.headers = append(info.headers, reflectField{index: sf.Index, name: name})
+		} else if name, ok := sf.Tag.Lookup("cookie"); ok && name != "-" {
+			if !isCookieType(sf.Type) || sf.PkgPath != "" {
+				return nil, fmt.Errorf("type %s: cookie field %s has unsupported type %s", t, sf.Name, sf.Type)
+			}
+			info.cookies = append(info.cookies, reflectField{index: sf.Index, name: name})
+		} else {
+			continue
+		}
+		if key, ok := jsonKey(sf); ok {
+			info.bodyKeys[key] = true
+		}
+	}
+	if info.status == nil && len(info.headers) == 0 && len(info.cookies) == 0 {
+		return nil, nil
+	}
+	return info, nil
+}
+
+// jsonKey returns the key of the struct field in its JSON object, if it is
+// encoded.
+func jsonKey(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return sf.Name, true
+}
+
+// removeJSONKeys removes the keys from the JSON object, keeping the order of
+// the other ones. Other JSON values are returned as is.
+func removeJSONKeys(data []byte, keys map[string]bool) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if keys[key] {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// apply sets the headers and cookies of the struct value, and returns its
+// status code, if any.
+func (info *reflectInfo) apply(w http.ResponseWriter, v reflect.Value) int {
+	for _, f := range info.headers {
+		fv := v.FieldByIndex(f.index)
+		if fv.IsZero() {
+			continue
+		}
+		for _, s := range headerValues(fv) {
+			w.Header().Add(f.name, s)
+		}
+	}
+	for _, f := range info.cookies {
+		fv := v.FieldByIndex(f.index)
+		if fv.IsZero() {
+			continue
+		}
+		switch c := fv.Interface().(type) {
+		case string:
+			http.SetCookie(w, &http.Cookie{Name: f.name, Value: c})
+		case http.Cookie:
+			if c.Name == "" {
+				c.Name = f.name
+			}
+			http.SetCookie(w, &c)
+		case *http.Cookie:
+			cc := *c
+			if cc.Name == "" {
+				cc.Name = f.name
+			}
+			http.SetCookie(w, &cc)
+		}
+	}
+	if info.status != nil {
+		sv := v.FieldByIndex(info.status)
+		switch sv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int(sv.Uint())
+		default:
+			return int(sv.Int())
+		}
+	}
+	return 0
+}
+
+func headerValues(v reflect.Value) []string {
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Slice {
+		ret := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret = append(ret, headerValues(v.Index(i))...)
+		}
+		return ret
+	}
+	switch x := v.Interface().(type) {
+	case time.Time:
+		return []string{x.UTC().Format(http.TimeFormat)}
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		if err != nil {
+			return nil
+		}
+		return []string{string(b)}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []string{v.String()}
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())}
+	}
+	return nil
+}
+
+func isHeaderType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t == timeType || t.Implements(textMarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isIntKind(t.Kind())
+}
+
+func isCookieType(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t == reflect.TypeOf(http.Cookie{}) || t == reflect.TypeOf(&http.Cookie{})
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
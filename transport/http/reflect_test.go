@@ -0,0 +1,243 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+type reflectMeta struct {
+	Version int `json:"version"`
+}
+
+type reflectResponse struct {
+	reflectMeta
+	Status   int          `http:"status"`
+	Location string       `header:"Location"`
+	Tags     []string     `header:"X-Tag"`
+	Count    *int         `header:"X-Count"`
+	Expires  time.Time    `header:"Expires"`
+	Session  *http.Cookie `cookie:"session"`
+	Theme    string       `cookie:"theme"`
+	ID       string       `json:"id"`
+	internal string
+}
+
+type reflectInterfaceResponse struct {
+	ID string `json:"id"`
+}
+
+func (reflectInterfaceResponse) StatusCode() int { return http.StatusAccepted }
+func (reflectInterfaceResponse) Headers() http.Header {
+	return http.Header{"X-Interface": []string{"yes"}}
+}
+func (reflectInterfaceResponse) Cookies() []*http.Cookie {
+	return []*http.Cookie{{Name: "c", Value: "v"}}
+}
+
+func TestReflectResponse(t *testing.T) {
+	count := 3
+	rec := httptest.NewRecorder()
+	err := httptransport.ReflectResponse[*reflectResponse]()(context.Background(), rec, &reflectResponse{
+		reflectMeta: reflectMeta{Version: 2},
+		Status:      http.StatusCreated,
+		Location:    "/profiles/1",
+		Tags:        []string{"a", "b"},
+		Count:       &count,
+		Expires:     time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		Session:     &http.Cookie{Value: "s1", HttpOnly: true},
+		ID:          "1",
+		internal:    "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := http.StatusCreated, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	for _, test := range []struct {
+		key, want string
+	}{
+		{"Location", "/profiles/1"},
+		{"X-Tag", "a,b"},
+		{"X-Count", "3"},
+		{"Expires", "Sun, 02 Jan 2022 03:04:05 GMT"},
+		{"Set-Cookie", "session=s1; HttpOnly"},
+		{"Content-Type", "application/json; charset=utf-8"},
+	} {
+		if have := strings.Join(rec.Header().Values(test.key), ","); test.want != have {
+			t.Errorf("%s: want %s, have %s", test.key, test.want, have)
+		}
+	}
+	if want, have := `{"version":2,"id":"1"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestReflectResponseZeroValues(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := httptransport.ReflectResponse[reflectResponse]()(context.Background(), rec, reflectResponse{ID: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := http.StatusOK, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	for _, key := range []string{"Location", "X-Tag", "X-Count", "Expires", "Set-Cookie"} {
+		if have := rec.Header().Get(key); have != "" {
+			t.Errorf("%s: want empty, have %s", key, have)
+		}
+	}
+	if want, have := `{"version":0,"id":"2"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestReflectResponseInterfaces(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := httptransport.ReflectResponse[reflectInterfaceResponse]()(context.Background(), rec, reflectInterfaceResponse{ID: "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := http.StatusAccepted, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "yes", rec.Header().Get("X-Interface"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "c=v", rec.Header().Get("Set-Cookie"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := `{"id":"3"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestReflectResponseNoContent(t *testing.T) {
+	type response struct {
+		Status int `http:"status"`
+	}
+	rec := httptest.NewRecorder()
+	err := httptransport.ReflectResponse[response]()(context.Background(), rec, response{Status: http.StatusNoContent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := http.StatusNoContent, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := 0, rec.Body.Len(); want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+type ReflectAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+func (a ReflectAudit) Creator() string { return a.CreatedBy }
+
+type reflectEmbeddedResponse struct {
+	Location string `header:"Location"`
+	ReflectAudit
+	reflectMeta
+	ID string `json:"id"`
+}
+
+type reflectOtherMeta struct {
+	Version int
+}
+
+type reflectDuplicateResponse struct {
+	Location string `header:"Location"`
+	reflectMeta
+	reflectOtherMeta
+	ID string `json:"id"`
+}
+
+type reflectMarshalerResponse struct {
+	Location string `header:"Location" json:"location"`
+	ID       string
+}
+
+func (r reflectMarshalerResponse) MarshalJSON() ([]byte, error) {
+	return []byte(`{"location":"` + r.Location + `","identifier":"` + r.ID + `"}`), nil
+}
+
+func TestReflectResponseBody(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		enc  func(w http.ResponseWriter) error
+		want string
+	}{
+		{"embedded with methods", func(w http.ResponseWriter) error {
+			return httptransport.ReflectResponse[reflectEmbeddedResponse]()(context.Background(), w,
+				reflectEmbeddedResponse{Location: "/a", ReflectAudit: ReflectAudit{CreatedBy: "john"},
+					reflectMeta: reflectMeta{Version: 1}, ID: "4"})
+		}, `{"created_by":"john","version":1,"id":"4"}`},
+		{"duplicate embedded fields", func(w http.ResponseWriter) error {
+			return httptransport.ReflectResponse[reflectDuplicateResponse]()(context.Background(), w,
+				reflectDuplicateResponse{Location: "/a", ID: "5"})
+		}, `{"version":0,"Version":0,"id":"5"}`},
+		{"marshaler", func(w http.ResponseWriter) error {
+			return httptransport.ReflectResponse[reflectMarshalerResponse]()(context.Background(), w,
+				reflectMarshalerResponse{Location: "/a", ID: "6"})
+		}, `{"identifier":"6"}`},
+	} {
+		rec := httptest.NewRecorder()
+		if err := test.enc(rec); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if want, have := "/a", rec.Header().Get("Location"); want != have {
+			t.Errorf("%s: want %s, have %s", test.name, want, have)
+		}
+		if want, have := test.want, strings.TrimSpace(rec.Body.String()); want != have {
+			t.Errorf("%s: want %s, have %s", test.name, want, have)
+		}
+	}
+}
+
+type reflectFailingResponse struct {
+	Status int `http:"status"`
+}
+
+func (reflectFailingResponse) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("dang")
+}
+
+func TestReflectResponseMarshalError(t *testing.T) {
+	server := httptransport.NewServer(
+		func(context.Context, struct{}) (reflectFailingResponse, error) {
+			return reflectFailingResponse{Status: http.StatusCreated}, nil
+		},
+		func(context.Context, *http.Request) (struct{}, error) { return struct{}{}, nil },
+		httptransport.ReflectResponse[reflectFailingResponse]())
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if want, have := http.StatusInternalServerError, rec.Code; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+}
+
+func TestReflectResponseNilHeaderElements(t *testing.T) {
+	type response struct {
+		Dates []*time.Time `header:"X-Date"`
+	}
+	date := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := httptest.NewRecorder()
+	err := httptransport.ReflectResponse[response]()(context.Background(), rec,
+		response{Dates: []*time.Time{nil, &date}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{date.Format(http.TimeFormat)}, rec.Header().Values("X-Date"); !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
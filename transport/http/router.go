@@ -0,0 +1,285 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrInvalidRoute = errors.New("invalid route")
+)
+
+// StructCodec decodes requests and encodes responses of the endpoints mounted
+// by NewRouter, where the concrete types are only known at runtime.
+type StructCodec struct {
+	// Decode decodes the HTTP request into the value pointed to by request.
+	Decode func(ctx context.Context, r *http.Request, request interface{}) error
+	// Encode encodes the response object to the HTTP response writer.
+	Encode func(ctx context.Context, w http.ResponseWriter, response interface{}) error
+}
+
+// JSONStructCodec is a StructCodec which decodes the request body as JSON, and
+// encodes the response using gokithttptransport.EncodeJSONResponse. An empty
+// request body decodes to the zero value of the request type.
+var JSONStructCodec = StructCodec{
+	Decode: func(_ context.Context, r *http.Request, request interface{}) error {
+		err := json.NewDecoder(r.Body).Decode(request)
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	},
+	Encode: gokithttptransport.EncodeJSONResponse,
+}
+
+// Route describes an endpoint mounted by the router.
+type Route struct {
+	// Name is the name of the endpoints struct field.
+	Name string
+	// Method is the HTTP method.
+	Method string
+	// Pattern is the path pattern.
+	Pattern string
+	// Codec is the name of the codec.
+	Codec string
+}
+
+// Router is an http.Handler which routes requests to the fields of an
+// endpoints struct.
+type Router struct {
+	codecs       map[string]StructCodec
+	defaultCodec string
+	options      []gokithttptransport.ServerOption
+	notFound     http.Handler
+	routes       []*route
+}
+
+type route struct {
+	Route
+	segments []string
+	handler  http.Handler
+}
+
+// RouterOption sets an optional parameter for routers.
+type RouterOption func(*Router)
+
+// RouterCodec registers a codec which can be selected with the "codec" field tag.
+func RouterCodec(name string, codec StructCodec) RouterOption {
+	return func(r *Router) { r.codecs[name] = codec }
+}
+
+// RouterDefaultCodec sets the codec used for fields without a "codec" tag.
+// By default, "json" is used.
+func RouterDefaultCodec(name string) RouterOption {
+	return func(r *Router) { r.defaultCodec = name }
+}
+
+// RouterServerOptions sets the options used for every endpoint server.
+func RouterServerOptions(options ...gokithttptransport.ServerOption) RouterOption {
+	return func(r *Router) { r.options = append(r.options, options...) }
+}
+
+// RouterNotFound sets the handler called when no route matches the request path.
+// By default, http.NotFoundHandler is used.
+func RouterNotFound(h http.Handler) RouterOption {
+	return func(r *Router) { r.notFound = h }
+}
+
+// NewRouter constructs a Router from the passed endpoints struct. Each endpoint
+// field with an "http" tag in the format "METHOD /path/{param}" is mounted with
+// its own Server, using the codec named in the "codec" tag:
+//
+//	type Endpoints struct {
+//	    PostProfileEndpoint endpoint.Endpoint[postProfileRequest, postProfileResponse] `http:"POST /profiles/"`
+//	    GetProfileEndpoint  endpoint.Endpoint[getProfileRequest, getProfileResponse]   `http:"GET /profiles/{id}" codec:"json"`
+//	}
+//
+// Path parameters are available to decoders with PathParams. Requests for a
+// known path with an unsupported method are answered with 405 Method Not
+// Allowed and an Allow header, and unknown paths with 404 Not Found.
+func NewRouter(endpoints interface{}, options ...RouterOption) (*Router, error) {
+	r := &Router{
+		codecs: map[string]StructCodec{
+			"json": JSONStructCodec,
+		},
+		defaultCodec: "json",
+		notFound:     http.NotFoundHandler(),
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	fields, err := endpoint.StructFields(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		tag, ok := field.Tag.Lookup("http")
+		if !ok {
+			continue
+		}
+		if field.Endpoint == nil {
+			return nil, fmt.Errorf("%w: endpoint %s is nil", ErrInvalidRoute, field.Name)
+		}
+		method, pattern, err := parseRouteTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: endpoint %s: %s", ErrInvalidRoute, field.Name, err)
+		}
+		codecName := r.defaultCodec
+		if c, ok := field.Tag.Lookup("codec"); ok {
+			codecName = c
+		}
+		codec, ok := r.codecs[codecName]
+		if !ok {
+			return nil, fmt.Errorf("%w: endpoint %s: unknown codec '%s'", ErrInvalidRoute, field.Name, codecName)
+		}
+
+		r.routes = append(r.routes, &route{
+			Route: Route{
+				Name:    field.Name,
+				Method:  method,
+				Pattern: pattern,
+				Codec:   codecName,
+			},
+			segments: strings.Split(pattern, "/"),
+			handler:  r.newFieldServer(field, codec),
+		})
+	}
+	return r, nil
+}
+
+// Routes returns the mounted routes.
+func (r *Router) Routes() []Route {
+	ret := make([]Route, 0, len(r.routes))
+	for _, rt := range r.routes {
+		ret = append(ret, rt.Route)
+	}
+	return ret
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	segments := strings.Split(req.URL.Path, "/")
+	var allow []string
+	for _, rt := range r.routes {
+		params, ok := matchRoute(rt.segments, segments)
+		if !ok {
+			continue
+		}
+		if rt.Method != req.Method {
+			if !containsString(allow, rt.Method) {
+				allow = append(allow, rt.Method)
+			}
+			continue
+		}
+		if len(params) > 0 {
+			req = req.WithContext(context.WithValue(req.Context(), contextKeyPathParams, params))
+		}
+		rt.handler.ServeHTTP(w, req)
+		return
+	}
+
+	if len(allow) == 0 {
+		r.notFound.ServeHTTP(w, req)
+		return
+	}
+	sort.Strings(allow)
+	w.Header().Set("Allow", strings.Join(allow, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+func (r *Router) newFieldServer(field endpoint.StructField, codec StructCodec) http.Handler {
+	requestType := field.Request
+	return NewServer[any, any](
+		endpoint.Endpoint[any, any](field.Endpoint),
+		func(ctx context.Context, req *http.Request) (interface{}, error) {
+			request := reflect.New(requestType)
+			if err := codec.Decode(ctx, req, request.Interface()); err != nil {
+				return nil, err
+			}
+			return request.Elem().Interface(), nil
+		},
+		func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+			return codec.Encode(ctx, w, response)
+		},
+		r.options...)
+}
+
+// PathParams returns the path parameters of the route matched by a Router.
+func PathParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(contextKeyPathParams).(map[string]string)
+	return params
+}
+
+// PathParam returns the path parameter with the passed name of the route
+// matched by a Router.
+func PathParam(ctx context.Context, name string) string {
+	return PathParams(ctx)[name]
+}
+
+type routerContextKey int
+
+const (
+	contextKeyPathParams routerContextKey = iota
+)
+
+func parseRouteTag(tag string) (method string, pattern string, err error) {
+	fields := strings.Fields(tag)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("route '%s' must be in the format 'METHOD /path'", tag)
+	}
+	method, pattern = strings.ToUpper(fields[0]), fields[1]
+	if !strings.HasPrefix(pattern, "/") {
+		return "", "", fmt.Errorf("path '%s' must start with '/'", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if isPatternParam(segment) && len(segment) == 2 {
+			return "", "", fmt.Errorf("path '%s' has an empty parameter name", pattern)
+		}
+	}
+	return method, pattern, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isPatternParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func matchRoute(pattern []string, path []string) (map[string]string, bool) {
+	if len(pattern) != len(path) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, segment := range pattern {
+		if isPatternParam(segment) {
+			if path[i] == "" {
+				return nil, false
+			}
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[segment[1:len(segment)-1]] = path[i]
+		} else if segment != path[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
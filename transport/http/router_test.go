@@ -0,0 +1,167 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+type routerProfile struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+type routerEndpoints struct {
+	PostProfile endpoint.Endpoint[routerProfile, routerProfile] `http:"POST /profiles/"`
+	GetProfile  endpoint.Endpoint[routerProfile, routerProfile] `http:"GET /profiles/{id}"`
+	PutProfile  endpoint.Endpoint[routerProfile, routerProfile] `http:"PUT /profiles/{id}" codec:"upper"`
+	NotMounted  endpoint.Endpoint[string, string]
+}
+
+func newRouterEndpoints() routerEndpoints {
+	return routerEndpoints{
+		PostProfile: func(_ context.Context, req routerProfile) (routerProfile, error) {
+			req.ID = "new"
+			return req, nil
+		},
+		GetProfile: func(ctx context.Context, req routerProfile) (routerProfile, error) {
+			return routerProfile{ID: req.ID, Name: "profile " + req.ID}, nil
+		},
+		PutProfile: func(ctx context.Context, req routerProfile) (routerProfile, error) {
+			return req, nil
+		},
+	}
+}
+
+func TestRouter(t *testing.T) {
+	upperCodec := httptransport.StructCodec{
+		Decode: func(ctx context.Context, r *http.Request, request interface{}) error {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return err
+			}
+			*(request.(*routerProfile)) = routerProfile{
+				ID:   httptransport.PathParam(ctx, "id"),
+				Name: strings.ToUpper(string(body)),
+			}
+			return nil
+		},
+		Encode: func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+			_, err := w.Write([]byte(response.(routerProfile).ID + ":" + response.(routerProfile).Name))
+			return err
+		},
+	}
+
+	router, err := httptransport.NewRouter(newRouterEndpoints(),
+		httptransport.RouterCodec("upper", upperCodec),
+		httptransport.RouterServerOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 3, len(router.Routes()); want != have {
+		t.Fatalf("want %d routes, have %d", want, have)
+	}
+
+	// the JSON codec doesn't bind path parameters, so GetProfile receives an empty ID
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for _, test := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+		allow  string
+		resp   string
+	}{
+		{"POST", "/profiles/", `{"name":"john"}`, http.StatusOK, "", `{"id":"new","name":"john"}`},
+		{"GET", "/profiles/12", "", http.StatusOK, "", `{"name":"profile "}`},
+		{"PUT", "/profiles/12", "mary", http.StatusOK, "", "12:MARY"},
+		{"DELETE", "/profiles/12", "", http.StatusMethodNotAllowed, "GET, PUT", ""},
+		{"GET", "/profiles/", "", http.StatusMethodNotAllowed, "POST", ""},
+		{"GET", "/profiles/12/addresses", "", http.StatusNotFound, "", ""},
+		{"GET", "/unknown", "", http.StatusNotFound, "", ""},
+	} {
+		req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if want, have := test.status, resp.StatusCode; want != have {
+			t.Errorf("%s %s: want %d, have %d (%s)", test.method, test.path, want, have, buf)
+		}
+		if want, have := test.allow, resp.Header.Get("Allow"); want != have {
+			t.Errorf("%s %s: Allow: want %q, have %q", test.method, test.path, want, have)
+		}
+		if test.resp != "" {
+			if want, have := test.resp, strings.TrimSpace(string(buf)); want != have {
+				t.Errorf("%s %s: want %s, have %s", test.method, test.path, want, have)
+			}
+		}
+	}
+}
+
+func TestRouterPathParams(t *testing.T) {
+	e := struct {
+		GetProfile endpoint.Endpoint[routerProfile, routerProfile] `http:"GET /profiles/{id}"`
+	}{
+		GetProfile: newRouterEndpoints().GetProfile,
+	}
+	codec := httptransport.JSONStructCodec
+	decode := codec.Decode
+	codec.Decode = func(ctx context.Context, r *http.Request, request interface{}) error {
+		if err := decode(ctx, r, request); err != nil {
+			return err
+		}
+		if id := httptransport.PathParam(ctx, "id"); id != "" {
+			request.(*routerProfile).ID = id
+		}
+		return nil
+	}
+
+	router, err := httptransport.NewRouter(&e, httptransport.RouterCodec("json", codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/profiles/42", nil))
+	if want, have := `{"id":"42","name":"profile 42"}`, strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestRouterInvalid(t *testing.T) {
+	for _, test := range []interface{}{
+		struct {
+			E endpoint.Endpoint[string, string] `http:"GET /x"`
+		}{},
+		struct {
+			E endpoint.Endpoint[string, string] `http:"/x"`
+		}{func(context.Context, string) (string, error) { return "", nil }},
+		struct {
+			E endpoint.Endpoint[string, string] `http:"GET /x/{}"`
+		}{func(context.Context, string) (string, error) { return "", nil }},
+		struct {
+			E endpoint.Endpoint[string, string] `http:"GET /x" codec:"unknown"`
+		}{func(context.Context, string) (string, error) { return "", nil }},
+	} {
+		if _, err := httptransport.NewRouter(test); !errors.Is(err, httptransport.ErrInvalidRoute) {
+			t.Errorf("expected ErrInvalidRoute, received %v", err)
+		}
+	}
+}
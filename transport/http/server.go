@@ -1,15 +1,18 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/RangelReale/go-kit-typed/endpoint"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
 	gokithttptransport "github.com/go-kit/kit/transport/http"
 )
 
 // Server wraps an endpoint and implements http.Handler.
 type Server[Req any, Resp any] struct {
 	server *gokithttptransport.Server
+	hooks  serverHooks[Req, Resp]
 }
 
 // NewServer constructs a new server, which implements http.Handler and wraps
@@ -20,14 +23,11 @@ func NewServer[Req any, Resp any](
 	enc EncodeResponseFunc[Resp],
 	options ...gokithttptransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokithttptransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
 }
 
 // NewServerStdDec constructs a new server, which implements http.Handler and wraps
@@ -38,14 +38,11 @@ func NewServerStdDec[Req any, Resp any](
 	enc EncodeResponseFunc[Resp],
 	options ...gokithttptransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokithttptransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		dec,
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
 }
 
 // NewServerStdEnc constructs a new server, which implements http.Handler and wraps
@@ -56,17 +53,135 @@ func NewServerStdEnc[Req any, Resp any](
 	enc gokithttptransport.EncodeResponseFunc,
 	options ...gokithttptransport.ServerOption,
 ) *Server[Req, Resp] {
-	server := gokithttptransport.NewServer(
+	return newServer[Req, Resp](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		enc,
-		options...)
-	return &Server[Req, Resp]{
-		server: server,
-	}
+		options)
+}
+
+// After adds functions which are executed on the HTTP response writer after
+// the endpoint is invoked, like the Go kit ServerAfter option, and also receive
+// the endpoint response. They are executed after the ServerAfter functions.
+// The typed hooks must be set before the server handles requests.
+func (s *Server[Req, Resp]) After(after ...ServerAfterFunc[Resp]) *Server[Req, Resp] {
+	s.hooks.after = append(s.hooks.after, after...)
+	return s
+}
+
+// ErrorHandler sets a handler for non-terminal errors, like the Go kit
+// ServerErrorHandler option, which also receives the decoded request. It is
+// called before the ServerErrorHandler.
+func (s *Server[Req, Resp]) ErrorHandler(h ServerErrorHandlerFunc[Req]) *Server[Req, Resp] {
+	s.hooks.errorHandler = h
+	return s
+}
+
+// Finalizer adds functions which are executed at the end of every HTTP
+// request, like the Go kit ServerFinalizer option, and also receive the
+// decoded request, the endpoint response and the error of the request. They
+// are executed after the ServerFinalizer functions.
+func (s *Server[Req, Resp]) Finalizer(f ...ServerFinalizerTypedFunc[Req, Resp]) *Server[Req, Resp] {
+	s.hooks.finalizer = append(s.hooks.finalizer, f...)
+	return s
 }
 
 // ServeHTTP implements http.Handler.
 func (s Server[Req, Resp]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.server.ServeHTTP(w, r)
 }
+
+// newServer constructs the server and its Go kit server. The default options
+// come first, so the passed options can override them. The endpoint, decoder
+// and encoder are wrapped, and after functions and a finalizer added after the
+// passed options, to run the typed hooks set later, which read the per-request
+// state from the context.
+func newServer[Req any, Resp any](e gokitendpoint.Endpoint, dec gokithttptransport.DecodeRequestFunc,
+	enc gokithttptransport.EncodeResponseFunc, options []gokithttptransport.ServerOption) *Server[Req, Resp] {
+	s := &Server[Req, Resp]{}
+	serverOptions := []gokithttptransport.ServerOption{
+		// the state must be in the context before any other request function runs.
+		gokithttptransport.ServerBefore(func(ctx context.Context, _ *http.Request) context.Context {
+			if s.hooks.empty() {
+				return ctx
+			}
+			return context.WithValue(ctx, serverStateContextKey{}, &serverState{})
+		}),
+		gokithttptransport.ServerBefore(populateRequestAccept),
+		gokithttptransport.ServerErrorEncoder(DefaultErrorEncoder),
+	}
+	serverOptions = append(serverOptions, options...)
+	serverOptions = append(serverOptions,
+		gokithttptransport.ServerAfter(func(ctx context.Context, w http.ResponseWriter) context.Context {
+			if state := serverStateFrom(ctx); state != nil {
+				response, _ := state.response.(Resp)
+				for _, f := range s.hooks.after {
+					ctx = f(ctx, w, response)
+				}
+			}
+			return ctx
+		}),
+		gokithttptransport.ServerFinalizer(func(ctx context.Context, code int, r *http.Request) {
+			if state := serverStateFrom(ctx); state != nil {
+				request, _ := state.request.(Req)
+				response, _ := state.response.(Resp)
+				for _, f := range s.hooks.finalizer {
+					f(ctx, code, r, request, response, state.err)
+				}
+			}
+		}),
+	)
+	s.server = gokithttptransport.NewServer(
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			response, err := e(ctx, request)
+			if state := serverStateFrom(ctx); state != nil {
+				state.response, state.err = response, err
+				s.handleError(ctx, state)
+			}
+			return response, err
+		},
+		func(ctx context.Context, r *http.Request) (interface{}, error) {
+			request, err := dec(ctx, r)
+			if state := serverStateFrom(ctx); state != nil {
+				state.request, state.err = request, err
+				s.handleError(ctx, state)
+			}
+			return request, err
+		},
+		func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+			err := enc(ctx, w, response)
+			if state := serverStateFrom(ctx); state != nil && err != nil {
+				state.err = err
+				s.handleError(ctx, state)
+			}
+			return err
+		},
+		serverOptions...)
+	return s
+}
+
+// handleError calls the typed error handler, if set, with the request recorded
+// in the state.
+func (s *Server[Req, Resp]) handleError(ctx context.Context, state *serverState) {
+	if s.hooks.errorHandler == nil || state.err == nil {
+		return
+	}
+	request, _ := state.request.(Req)
+	s.hooks.errorHandler(ctx, request, state.err)
+}
+
+// serverState is the per-request state used by the typed hooks.
+type serverState struct {
+	request  interface{}
+	response interface{}
+	err      error
+}
+
+type serverStateContextKey struct{}
+
+// serverStateFrom returns the state of the request, or nil when no typed hooks
+// are set.
+func serverStateFrom(ctx context.Context) *serverState {
+	state, _ := ctx.Value(serverStateContextKey{}).(*serverState)
+	return state
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/transport"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// NativeServerOption sets an optional parameter for native servers. Server
+// accepts the Go kit server options instead.
+type NativeServerOption func(*serverOptions)
+
+type serverOptions struct {
+	before       []gokithttptransport.RequestFunc
+	after        []gokithttptransport.ServerResponseFunc
+	errorEncoder gokithttptransport.ErrorEncoder
+	errorHandler transport.ErrorHandler
+	finalizer    []gokithttptransport.ServerFinalizerFunc
+}
+
+func newServerOptions(options []NativeServerOption) serverOptions {
+	sopt := serverOptions{
+		before:       []gokithttptransport.RequestFunc{populateRequestAccept},
+		errorEncoder: DefaultErrorEncoder,
+	}
+	for _, opt := range options {
+		opt(&sopt)
+	}
+	return sopt
+}
+
+// NativeServerBefore functions are executed on the HTTP request object before
+// the request is decoded.
+func NativeServerBefore(before ...gokithttptransport.RequestFunc) NativeServerOption {
+	return func(s *serverOptions) { s.before = append(s.before, before...) }
+}
+
+// NativeServerAfter functions are executed on the HTTP response writer after
+// the endpoint is invoked, but before anything is written to the client.
+func NativeServerAfter(after ...gokithttptransport.ServerResponseFunc) NativeServerOption {
+	return func(s *serverOptions) { s.after = append(s.after, after...) }
+}
+
+// NativeServerErrorEncoder is used to encode errors to the http.ResponseWriter
+// whenever they're encountered in the processing of a request. Clients can
+// use this to provide custom error formatting and response codes. By default,
+// errors will be written with the DefaultErrorEncoder.
+func NativeServerErrorEncoder(ee gokithttptransport.ErrorEncoder) NativeServerOption {
+	return func(s *serverOptions) { s.errorEncoder = ee }
+}
+
+// NativeServerErrorHandler is used to handle non-terminal errors. By default,
+// non-terminal errors are ignored. This is intended as a diagnostic measure.
+// Finer-grained control of error handling, including logging in more detail,
+// should be performed in a custom NativeServerErrorEncoder or
+// NativeServerFinalizer, both of which have access to the context.
+func NativeServerErrorHandler(errorHandler transport.ErrorHandler) NativeServerOption {
+	return func(s *serverOptions) { s.errorHandler = errorHandler }
+}
+
+// NativeServerFinalizer is executed at the end of every HTTP request.
+// By default, no finalizer is registered.
+func NativeServerFinalizer(f ...gokithttptransport.ServerFinalizerFunc) NativeServerOption {
+	return func(s *serverOptions) { s.finalizer = append(s.finalizer, f...) }
+}
+
+// ServerAfterFunc is a typed ServerAfter function, which receives the response
+// returned by the endpoint.
+type ServerAfterFunc[Resp any] func(ctx context.Context, w http.ResponseWriter, response Resp) context.Context
+
+// ServerErrorHandlerFunc is a typed error handler, which receives the decoded
+// request, or its zero value if the error happened while decoding.
+type ServerErrorHandlerFunc[Req any] func(ctx context.Context, request Req, err error)
+
+// ServerFinalizerTypedFunc is a typed ServerFinalizer function, which receives
+// the decoded request, the endpoint response and the error of the request, if
+// any. The request and response are zero values when the request failed
+// before they were available.
+type ServerFinalizerTypedFunc[Req any, Resp any] func(ctx context.Context, code int, r *http.Request,
+	request Req, response Resp, err error)
+
+// serverHooks are the typed hooks of a server, set with its After,
+// ErrorHandler and Finalizer methods.
+type serverHooks[Req any, Resp any] struct {
+	after        []ServerAfterFunc[Resp]
+	errorHandler ServerErrorHandlerFunc[Req]
+	finalizer    []ServerFinalizerTypedFunc[Req, Resp]
+}
+
+func (h serverHooks[Req, Resp]) empty() bool {
+	return len(h.after) == 0 && h.errorHandler == nil && len(h.finalizer) == 0
+}
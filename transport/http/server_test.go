@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
 	"github.com/RangelReale/go-kit-typed/util"
 	gokitendpoint "github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/transport"
 	gokithttptransport "github.com/go-kit/kit/transport/http"
 )
 
@@ -559,3 +561,108 @@ func testServer(t *testing.T) (step func(), resp <-chan *http.Response) {
 	}()
 	return func() { stepch <- true }, response
 }
+
+func TestServerTypedHooks(t *testing.T) {
+	var (
+		afterResp     serverResp
+		finalizerReq  serverReq
+		finalizerResp serverResp
+		finalizerCode int
+		finalizerErr  error
+		done          = make(chan struct{})
+	)
+	handler := httptransport.NewServer[serverReq, serverResp](
+		func(_ context.Context, req serverReq) (serverResp, error) { return serverResp{req.req + "-resp"}, nil },
+		func(context.Context, *http.Request) (serverReq, error) { return serverReq{"req1"}, nil },
+		func(_ context.Context, w http.ResponseWriter, _ serverResp) error {
+			w.WriteHeader(http.StatusAccepted)
+			return nil
+		},
+	).After(func(ctx context.Context, w http.ResponseWriter, resp serverResp) context.Context {
+		afterResp = resp
+		return ctx
+	}).Finalizer(func(_ context.Context, code int, _ *http.Request, req serverReq, resp serverResp, err error) {
+		finalizerCode, finalizerReq, finalizerResp, finalizerErr = code, req, resp, err
+		close(done)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	_, _ = http.Get(server.URL)
+	<-done
+
+	if want, have := "req1-resp", afterResp.resp; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := http.StatusAccepted, finalizerCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+	if want, have := "req1", finalizerReq.req; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "req1-resp", finalizerResp.resp; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if finalizerErr != nil {
+		t.Errorf("want nil error, have %v", finalizerErr)
+	}
+}
+
+func TestServerTypedErrorHandler(t *testing.T) {
+	var (
+		handlerReq serverReq
+		handlerErr error
+		done       = make(chan struct{})
+	)
+	handler := httptransport.NewServer[serverReq, serverResp](
+		func(context.Context, serverReq) (serverResp, error) { return serverResp{}, errors.New("dang") },
+		func(context.Context, *http.Request) (serverReq, error) { return serverReq{"req1"}, nil },
+		func(context.Context, http.ResponseWriter, serverResp) error { return nil },
+	).ErrorHandler(func(_ context.Context, req serverReq, err error) {
+		handlerReq, handlerErr = req, err
+	}).Finalizer(func(_ context.Context, _ int, _ *http.Request, _ serverReq, _ serverResp, err error) {
+		if err == nil {
+			t.Error("want finalizer error, have nil")
+		}
+		close(done)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	_, _ = http.Get(server.URL)
+	<-done
+
+	if want, have := "req1", handlerReq.req; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if want, have := "dang", handlerErr.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestServerTypedAndGokitErrorHandler(t *testing.T) {
+	var (
+		calls []string
+		done  = make(chan struct{})
+	)
+	handler := httptransport.NewServer[serverReq, serverResp](
+		func(context.Context, serverReq) (serverResp, error) { return serverResp{}, errors.New("dang") },
+		func(context.Context, *http.Request) (serverReq, error) { return serverReq{"req1"}, nil },
+		func(context.Context, http.ResponseWriter, serverResp) error { return nil },
+		gokithttptransport.ServerErrorHandler(transport.ErrorHandlerFunc(func(context.Context, error) {
+			calls = append(calls, "gokit")
+		})),
+		gokithttptransport.ServerFinalizer(func(context.Context, int, *http.Request) { close(done) }),
+	).ErrorHandler(func(_ context.Context, req serverReq, _ error) {
+		calls = append(calls, "typed "+req.req)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	_, _ = http.Get(server.URL)
+	<-done
+
+	if want, have := []string{"typed req1", "gokit"}, calls; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
@@ -0,0 +1,429 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	"github.com/RangelReale/go-kit-typed/errkind"
+)
+
+// SSEContentType is the content type of Server-Sent Events streams.
+const SSEContentType = "text/event-stream"
+
+// SSEEventError is the event type used to send errors which happen after the
+// stream started.
+const SSEEventError = "error"
+
+// SSEEvent is a Server-Sent Event.
+type SSEEvent struct {
+	// ID is the event ID, sent back by clients in the Last-Event-ID header when
+	// reconnecting.
+	ID string
+	// Event is the event type. When empty, clients assume "message".
+	Event string
+	// Data is the event payload. It may contain newlines.
+	Data string
+	// Retry is the reconnection time hint, if not zero.
+	Retry time.Duration
+}
+
+// SSEEncodeFunc encodes a response as a Server-Sent Event.
+type SSEEncodeFunc[Resp any] func(context.Context, Resp) (SSEEvent, error)
+
+// SSEDecodeFunc decodes a Server-Sent Event into a response.
+type SSEDecodeFunc[Resp any] func(context.Context, SSEEvent) (Resp, error)
+
+// EncodeSSEJSON is an SSEEncodeFunc that serializes the response as JSON in
+// the event data.
+func EncodeSSEJSON[Resp any](_ context.Context, response Resp) (SSEEvent, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return SSEEvent{}, err
+	}
+	return SSEEvent{Data: string(data)}, nil
+}
+
+// DecodeSSEJSON is an SSEDecodeFunc that deserializes the JSON event data into
+// the response type.
+func DecodeSSEJSON[Resp any](_ context.Context, event SSEEvent) (Resp, error) {
+	var response Resp
+	err := json.Unmarshal([]byte(event.Data), &response)
+	return response, err
+}
+
+// SSERetry sets the reconnection time hint sent to clients when the stream
+// starts.
+func SSERetry(retry time.Duration) StreamServerOption {
+	return func(s *streamServerOptions) { s.retry = retry }
+}
+
+// LastEventID returns the Last-Event-ID sent by a reconnecting client, to be
+// used by SSE endpoints to resume the stream. On the client side, it returns
+// the value set with WithLastEventID.
+func LastEventID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKeyLastEventID).(string)
+	return id
+}
+
+// WithLastEventID returns a context which makes SSE clients send the event ID
+// in the Last-Event-ID header, to resume a previous stream.
+func WithLastEventID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeyLastEventID, id)
+}
+
+type sseContextKey int
+
+const (
+	contextKeyLastEventID sseContextKey = iota
+)
+
+// SSEServer wraps a streaming endpoint and implements http.Handler, sending
+// each response as a Server-Sent Event.
+//
+// The response headers are written with the first event or heartbeat, so
+// errors which happen before it are written by the error encoder. Errors which
+// happen later are sent as an event of type "error", with a JSON object with
+// the message and the errkind kind of the error as data. The request context
+// is canceled when the client disconnects.
+type SSEServer[Req any, Resp any] struct {
+	e       endpoint.ServerStreamEndpoint[Req, Resp]
+	dec     DecodeRequestFunc[Req]
+	enc     SSEEncodeFunc[Resp]
+	options streamServerOptions
+}
+
+// NewSSEServer constructs a new SSE server, which implements http.Handler and
+// wraps the provided streaming endpoint.
+func NewSSEServer[Req any, Resp any](
+	e endpoint.ServerStreamEndpoint[Req, Resp],
+	dec DecodeRequestFunc[Req],
+	enc SSEEncodeFunc[Resp],
+	options ...StreamServerOption,
+) *SSEServer[Req, Resp] {
+	return &SSEServer[Req, Resp]{
+		e:       e,
+		dec:     dec,
+		enc:     enc,
+		options: newStreamServerOptions(options),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s SSEServer[Req, Resp]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(context.WithValue(r.Context(), contextKeyLastEventID, r.Header.Get("Last-Event-ID")))
+	serveStream(s.options, s.dec, w, r, func(ctx context.Context, w http.ResponseWriter, request Req) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		sw := newSSEWriter(w, s.options.retry)
+		stop := startHeartbeat(ctx, s.options.heartbeat, sw.heartbeat)
+		err := s.e(ctx, request, func(response Resp) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			event, err := s.enc(ctx, response)
+			if err != nil {
+				return err
+			}
+			return sw.write(event)
+		})
+		stop()
+
+		if err != nil {
+			s.options.handleError(ctx, err)
+			if !sw.isStarted() {
+				s.options.errorEncoder(ctx, err, w)
+				return
+			}
+			if ctx.Err() == nil {
+				_ = sw.write(SSEEvent{Event: SSEEventError, Data: encodeSSEError(err)})
+			}
+		}
+	})
+}
+
+// sseError is the data of the events of type "error".
+type sseError struct {
+	Message string       `json:"message"`
+	Kind    errkind.Kind `json:"kind,omitempty"`
+}
+
+func encodeSSEError(err error) string {
+	data, _ := json.Marshal(sseError{Message: err.Error(), Kind: errkind.KindOf(err)})
+	return string(data)
+}
+
+// decodeSSEError returns the error sent in the data of an event of type
+// "error". Data which isn't a JSON object is used as the message.
+func decodeSSEError(data string) *StreamError {
+	var e sseError
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return &StreamError{Message: data}
+	}
+	return &StreamError{Message: e.Message, Kind: e.Kind}
+}
+
+// sseWriter writes events to the response, starting it with the first write.
+type sseWriter struct {
+	*flushWriter
+}
+
+func newSSEWriter(w http.ResponseWriter, retry time.Duration) sseWriter {
+	return sseWriter{&flushWriter{w: w, start: func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", SSEContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		if retry > 0 {
+			_, _ = fmt.Fprintf(w, "retry: %d\n\n", retry.Milliseconds())
+		}
+	}}}
+}
+
+func (sw sseWriter) write(event SSEEvent) error {
+	var b strings.Builder
+	if event.ID != "" {
+		b.WriteString("id: " + sseSanitize(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + sseSanitize(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(event.Data, "\r\n", "\n"), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return sw.writeString(b.String())
+}
+
+func (sw sseWriter) heartbeat() error {
+	return sw.writeString(": heartbeat\n\n")
+}
+
+func sseSanitize(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// SSEReconnect makes SSE clients reconnect when the connection is lost before
+// the server ends the stream, up to the passed number of consecutive attempts.
+// The Last-Event-ID header is set to the ID of the last received event, and
+// the client waits for the retry hint sent by the server, or one second.
+func SSEReconnect(attempts int) StreamClientOption {
+	return func(c *streamClientOptions) { c.reconnect = attempts }
+}
+
+// SSEClient wraps a URL and provides a method that implements
+// endpoint.ServerStreamEndpoint, decoding each received Server-Sent Event
+// into a response.
+type SSEClient[Req any, Resp any] struct {
+	req     CreateRequestFunc[Req]
+	dec     SSEDecodeFunc[Resp]
+	options streamClientOptions
+}
+
+// NewSSEClient constructs a usable SSEClient for a single remote method.
+func NewSSEClient[Req any, Resp any](method string, tgt *url.URL, enc EncodeRequestFunc[Req],
+	dec SSEDecodeFunc[Resp], options ...StreamClientOption) *SSEClient[Req, Resp] {
+	return &SSEClient[Req, Resp]{
+		req:     makeCreateRequestFunc(method, tgt, enc),
+		dec:     dec,
+		options: newStreamClientOptions(options),
+	}
+}
+
+// Endpoint returns a usable streaming endpoint that calls the remote SSE
+// endpoint, calling send for each received event. Events of type "error" are
+// returned as a *StreamError.
+func (c SSEClient[Req, Resp]) Endpoint() endpoint.ServerStreamEndpoint[Req, Resp] {
+	return func(ctx context.Context, request Req, send func(Resp) error) (err error) {
+		if len(c.options.finalizer) > 0 {
+			defer func() {
+				for _, f := range c.options.finalizer {
+					f(ctx, err)
+				}
+			}()
+		}
+
+		state := sseClientState{lastEventID: LastEventID(ctx), retry: time.Second}
+		for attempt := 0; ; attempt++ {
+			var received bool
+			received, err = c.stream(ctx, request, &state, send)
+			var connErr *sseConnError
+			if !errors.As(err, &connErr) {
+				return err
+			}
+			err = connErr.err
+			if received {
+				attempt = 0
+			}
+			if attempt >= c.options.reconnect {
+				return err
+			}
+			select {
+			case <-time.After(state.retry):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+type sseClientState struct {
+	lastEventID string
+	retry       time.Duration
+}
+
+// sseConnError is a connection error which allows reconnecting.
+type sseConnError struct {
+	err error
+}
+
+func (e *sseConnError) Error() string {
+	return e.err.Error()
+}
+
+// stream makes one request, returning whether any event was received.
+func (c SSEClient[Req, Resp]) stream(ctx context.Context, request Req, state *sseClientState,
+	send func(Resp) error) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := c.req(ctx, request)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", SSEContentType)
+	if state.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", state.lastEventID)
+	}
+
+	for _, f := range c.options.before {
+		ctx = f(ctx, req)
+	}
+
+	resp, err := c.options.client.Do(req.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, err
+		}
+		return false, &sseConnError{err}
+	}
+	defer resp.Body.Close()
+
+	for _, f := range c.options.after {
+		ctx = f(ctx, resp)
+	}
+
+	if err := c.options.checkResponse(ctx, resp); err != nil {
+		return false, err
+	}
+
+	received := false
+	err = readSSE(resp.Body, func(event SSEEvent, message bool) error {
+		if event.ID != "" {
+			state.lastEventID = event.ID
+		}
+		if event.Retry > 0 {
+			state.retry = event.Retry
+		}
+		if !message {
+			return nil
+		}
+		if event.Event == SSEEventError {
+			return decodeSSEError(event.Data)
+		}
+		received = true
+		response, err := c.dec(ctx, event)
+		if err != nil {
+			return err
+		}
+		return send(response)
+	})
+	if err != nil && ctx.Err() == nil && isSSEReadError(err) {
+		return received, &sseConnError{err}
+	}
+	return received, err
+}
+
+// sseReadError is an error reading the stream.
+type sseReadError struct {
+	err error
+}
+
+func (e sseReadError) Error() string {
+	return e.err.Error()
+}
+
+func (e sseReadError) Unwrap() error {
+	return e.err
+}
+
+func isSSEReadError(err error) bool {
+	var re sseReadError
+	return errors.As(err, &re)
+}
+
+// readSSE parses the event stream, calling f for each event, until the end of
+// the stream or an error. Events which only set the retry hint or the ID are
+// also dispatched, with message false; events with a data or event field,
+// even if empty, have message true.
+func readSSE(r io.Reader, f func(event SSEEvent, message bool) error) error {
+	reader := bufio.NewReader(r)
+	var (
+		event   SSEEvent
+		data    []string
+		pending bool
+		message bool
+	)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return sseReadError{err}
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if pending {
+				event.Data = strings.Join(data, "\n")
+				if err := f(event, message); err != nil {
+					return err
+				}
+			}
+			event, data, pending, message = SSEEvent{}, nil, false, false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := cutString(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID, pending = value, true
+		case "event":
+			event.Event, pending, message = value, true, true
+		case "data":
+			data, pending, message = append(data, value), true, true
+		case "retry":
+			if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+				event.Retry, pending = time.Duration(ms)*time.Millisecond, true
+			}
+		}
+	}
+}
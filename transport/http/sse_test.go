@@ -0,0 +1,287 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/endpoint"
+	httptransport "github.com/RangelReale/go-kit-typed/transport/http"
+)
+
+type sseRequest struct {
+	Count int
+}
+
+type sseResponse struct {
+	N int `json:"n"`
+}
+
+func decodeSSERequest(_ context.Context, r *http.Request) (sseRequest, error) {
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	return sseRequest{Count: count}, nil
+}
+
+func encodeSSERequest(_ context.Context, r *http.Request, req sseRequest) error {
+	r.URL.RawQuery = "count=" + strconv.Itoa(req.Count)
+	return nil
+}
+
+func encodeSSEResponse(ctx context.Context, resp sseResponse) (httptransport.SSEEvent, error) {
+	event, err := httptransport.EncodeSSEJSON(ctx, resp)
+	event.ID = strconv.Itoa(resp.N)
+	return event, err
+}
+
+// countEndpoint sends the numbers after the last event ID up to the requested
+// count.
+func countEndpoint(ctx context.Context, req sseRequest, send func(sseResponse) error) error {
+	start, _ := strconv.Atoi(httptransport.LastEventID(ctx))
+	for i := start + 1; i <= req.Count; i++ {
+		if err := send(sseResponse{N: i}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectSSE(ctx context.Context, e endpoint.ServerStreamEndpoint[sseRequest, sseResponse], req sseRequest) ([]int, error) {
+	var ns []int
+	err := e(ctx, req, func(resp sseResponse) error {
+		ns = append(ns, resp.N)
+		return nil
+	})
+	return ns, err
+}
+
+func TestSSEServerClient(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewSSEServer(countEndpoint, decodeSSERequest, encodeSSEResponse,
+		httptransport.SSERetry(500*time.Millisecond)))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse])
+
+	ns, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []int{1, 2, 3}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	ns, err = collectSSE(httptransport.WithLastEventID(context.Background(), "1"), client.Endpoint(), sseRequest{Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []int{2, 3}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestSSEServerWireFormat(t *testing.T) {
+	handler := httptransport.NewSSEServer(
+		func(_ context.Context, _ sseRequest, send func(string) error) error {
+			return send("line1\nline2")
+		},
+		decodeSSERequest,
+		func(_ context.Context, s string) (httptransport.SSEEvent, error) {
+			return httptransport.SSEEvent{ID: "7", Event: "lines", Data: s}, nil
+		},
+		httptransport.SSERetry(2*time.Second),
+	)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if want, have := httptransport.SSEContentType, rec.Header().Get("Content-Type"); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "retry: 2000\n\nid: 7\nevent: lines\ndata: line1\ndata: line2\n\n", rec.Body.String(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestSSEErrors(t *testing.T) {
+	server := httptest.NewServer(httptransport.NewSSEServer(
+		func(ctx context.Context, req sseRequest, send func(sseResponse) error) error {
+			if req.Count < 0 {
+				return httptransport.NewStatusError(http.StatusBadRequest, errors.New("negative count"))
+			}
+			if err := send(sseResponse{N: 1}); err != nil {
+				return err
+			}
+			return errors.New("failed after start")
+		},
+		decodeSSERequest,
+		encodeSSEResponse,
+	))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse])
+
+	_, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: -1})
+	var respErr *httptransport.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("want ResponseError, have %v", err)
+	}
+	if want, have := http.StatusBadRequest, respErr.StatusCode; want != have {
+		t.Errorf("want %d, have %d", want, have)
+	}
+
+	ns, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{Count: 1})
+	var streamErr *httptransport.StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("want StreamError, have %v", err)
+	}
+	if want, have := "failed after start", streamErr.Message; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := []int{1}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestSSEHeartbeat(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(httptransport.NewSSEServer(
+		func(ctx context.Context, _ sseRequest, _ func(sseResponse) error) error {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+		decodeSSERequest,
+		encodeSSEResponse,
+		httptransport.StreamHeartbeat(10*time.Millisecond),
+	))
+	defer server.Close()
+	defer close(release)
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := ": heartbeat\n", line; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestSSEClientDisconnect(t *testing.T) {
+	canceled := make(chan error, 1)
+	server := httptest.NewServer(httptransport.NewSSEServer(
+		func(ctx context.Context, _ sseRequest, send func(sseResponse) error) error {
+			for i := 1; ; i++ {
+				if err := send(sseResponse{N: i}); err != nil {
+					canceled <- err
+					return err
+				}
+				time.Sleep(time.Millisecond)
+			}
+		},
+		decodeSSERequest,
+		encodeSSEResponse,
+	))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse])
+	stop := errors.New("stop")
+	err := client.Endpoint()(context.Background(), sseRequest{}, func(resp sseResponse) error {
+		if resp.N == 2 {
+			return stop
+		}
+		return nil
+	})
+	if want, have := stop, err; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	select {
+	case err := <-canceled:
+		if err == nil {
+			t.Error("want send error, have nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server endpoint was not canceled")
+	}
+}
+
+func TestSSEClientReconnect(t *testing.T) {
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		w.Header().Set("Content-Type", httptransport.SSEContentType)
+		if len(lastEventIDs) == 1 {
+			fmt.Fprint(w, "retry: 1\n\nid: 1\ndata: {\"n\":1}\n\nid: 2\ndata: {\"n\":2}\n\n")
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		fmt.Fprint(w, "id: 3\ndata: {\"n\":3}\n\n")
+	}))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		httptransport.DecodeSSEJSON[sseResponse], httptransport.SSEReconnect(1))
+	ns, err := collectSSE(context.Background(), client.Endpoint(), sseRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []int{1, 2, 3}, ns; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := []string{"", "2"}, lastEventIDs; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestSSEServerEmptyStream(t *testing.T) {
+	handler := httptransport.NewSSEServer(
+		func(_ context.Context, _ sseRequest, send func(string) error) error { return nil },
+		decodeSSERequest,
+		func(_ context.Context, s string) (httptransport.SSEEvent, error) {
+			return httptransport.SSEEvent{Data: s}, nil
+		},
+	)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if want, have := "", strings.TrimSpace(rec.Body.String()); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestSSEClientEmptyData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", httptransport.SSEContentType)
+		fmt.Fprint(w, ": comment\n\nretry: 1000\n\nid: 1\n\ndata:\n\ndata: x\n\n")
+	}))
+	defer server.Close()
+
+	client := httptransport.NewSSEClient("GET", mustParse(server.URL), encodeSSERequest,
+		func(_ context.Context, event httptransport.SSEEvent) (string, error) { return event.Data, nil })
+	var data []string
+	err := client.Endpoint()(context.Background(), sseRequest{}, func(s string) error {
+		data = append(data, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"", "x"}, data; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
@@ -0,0 +1,243 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"github.com/go-kit/kit/transport"
+	gokithttptransport "github.com/go-kit/kit/transport/http"
+)
+
+// StreamError is an error sent by a streaming server after the stream started,
+// when the status code can no longer be changed. Streaming clients return it
+// when they receive one.
+type StreamError struct {
+	Message string
+	// Kind is the errkind kind of the error, if the stream format sends it.
+	Kind errkind.Kind
+}
+
+// Error implements error.
+func (e *StreamError) Error() string {
+	return e.Message
+}
+
+// ErrorKind implements errkind.Kinder. Errors without a kind are
+// errkind.Unknown.
+func (e *StreamError) ErrorKind() errkind.Kind {
+	if e.Kind == "" {
+		return errkind.Unknown
+	}
+	return e.Kind
+}
+
+// Is reports whether target is the errkind kind of the error.
+func (e *StreamError) Is(target error) bool {
+	kind, ok := target.(errkind.Kind)
+	return ok && kind == e.ErrorKind()
+}
+
+// StreamServerOption sets an optional parameter for streaming servers.
+type StreamServerOption func(*streamServerOptions)
+
+type streamServerOptions struct {
+	before       []gokithttptransport.RequestFunc
+	errorEncoder gokithttptransport.ErrorEncoder
+	errorHandler transport.ErrorHandler
+	finalizer    []gokithttptransport.ServerFinalizerFunc
+	heartbeat    time.Duration
+	retry        time.Duration
+}
+
+func newStreamServerOptions(options []StreamServerOption) streamServerOptions {
+	sopt := streamServerOptions{
+		errorEncoder: DefaultErrorEncoder,
+	}
+	for _, opt := range options {
+		opt(&sopt)
+	}
+	return sopt
+}
+
+// StreamServerBefore functions are executed on the HTTP request object before
+// the request is decoded.
+func StreamServerBefore(before ...gokithttptransport.RequestFunc) StreamServerOption {
+	return func(s *streamServerOptions) { s.before = append(s.before, before...) }
+}
+
+// StreamServerErrorEncoder is used to encode errors which happen before the
+// stream starts. By default, errors are written with the DefaultErrorEncoder.
+func StreamServerErrorEncoder(ee gokithttptransport.ErrorEncoder) StreamServerOption {
+	return func(s *streamServerOptions) { s.errorEncoder = ee }
+}
+
+// StreamServerErrorHandler is used to handle non-terminal errors. By default,
+// non-terminal errors are ignored.
+func StreamServerErrorHandler(errorHandler transport.ErrorHandler) StreamServerOption {
+	return func(s *streamServerOptions) { s.errorHandler = errorHandler }
+}
+
+// StreamServerFinalizer is executed at the end of every HTTP request, after
+// the stream ends. By default, no finalizer is registered.
+func StreamServerFinalizer(f ...gokithttptransport.ServerFinalizerFunc) StreamServerOption {
+	return func(s *streamServerOptions) { s.finalizer = append(s.finalizer, f...) }
+}
+
+// StreamHeartbeat sets the interval of the heartbeats written to idle streams,
+// to keep intermediaries from closing the connection. By default, no heartbeat
+// is sent.
+func StreamHeartbeat(interval time.Duration) StreamServerOption {
+	return func(s *streamServerOptions) { s.heartbeat = interval }
+}
+
+// StreamClientOption sets an optional parameter for streaming clients.
+type StreamClientOption func(*streamClientOptions)
+
+type streamClientOptions struct {
+	clientOptions
+	reconnect int
+}
+
+func newStreamClientOptions(options []StreamClientOption) streamClientOptions {
+	copt := streamClientOptions{
+		clientOptions: newClientOptions(nil),
+	}
+	for _, opt := range options {
+		opt(&copt)
+	}
+	return copt
+}
+
+// StreamClientOptions applies client options to a streaming client. The
+// client, before, after and finalizer functions and the error response
+// decoders are used; NativeBufferedStream is ignored.
+func StreamClientOptions(options ...NativeClientOption) StreamClientOption {
+	return func(c *streamClientOptions) {
+		for _, opt := range options {
+			opt(&c.clientOptions)
+		}
+	}
+}
+
+// checkResponse returns the error of a response which can't be streamed,
+// decoded by the error response decoders or built from the body.
+func (c streamClientOptions) checkResponse(ctx context.Context, resp *http.Response) error {
+	if err := c.decodeError(ctx, resp); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &ResponseError{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Err:        DecodeTextErrorResponse(ctx, resp),
+		}
+	}
+	return nil
+}
+
+func (s streamServerOptions) handleError(ctx context.Context, err error) {
+	if s.errorHandler != nil {
+		s.errorHandler.Handle(ctx, err)
+	}
+}
+
+// serveStream runs the parts common to the streaming servers: finalizers,
+// before functions and decoding, and calls serve with the decoded request. If
+// decoding fails, the error is encoded and serve is not called.
+func serveStream[Req any](s streamServerOptions, dec DecodeRequestFunc[Req], w http.ResponseWriter,
+	r *http.Request, serve func(ctx context.Context, w http.ResponseWriter, request Req)) {
+	ctx := r.Context()
+
+	if len(s.finalizer) > 0 {
+		iw := &interceptingWriter{ResponseWriter: w, code: http.StatusOK}
+		defer func() {
+			ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseHeaders, iw.Header())
+			ctx = context.WithValue(ctx, gokithttptransport.ContextKeyResponseSize, iw.written)
+			for _, f := range s.finalizer {
+				f(ctx, iw.code, r)
+			}
+		}()
+		w = iw
+	}
+
+	for _, f := range s.before {
+		ctx = f(ctx, r)
+	}
+
+	request, err := dec(ctx, r)
+	if err != nil {
+		s.handleError(ctx, err)
+		s.errorEncoder(ctx, err, w)
+		return
+	}
+
+	serve(ctx, w, request)
+}
+
+// startHeartbeat calls f at every interval until the returned function is
+// called, which waits for any running call to finish.
+func startHeartbeat(ctx context.Context, interval time.Duration, f func() error) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if f() != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// flushWriter writes to the response and flushes it after each write, calling
+// start before the first one. It is safe for concurrent use, so heartbeats can
+// be written while the endpoint is running.
+type flushWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	start   func(http.ResponseWriter)
+	started bool
+}
+
+func (fw *flushWriter) isStarted() bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.started
+}
+
+func (fw *flushWriter) writeString(s string) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if !fw.started {
+		fw.started = true
+		fw.start(fw.w)
+	}
+	if _, err := io.WriteString(fw.w, s); err != nil {
+		return err
+	}
+	if f, ok := fw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
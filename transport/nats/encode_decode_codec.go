@@ -0,0 +1,58 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	"github.com/nats-io/nats.go"
+)
+
+// DecodeRequestFuncFromCodec returns a DecodeRequestFunc which decodes the
+// Data of the Msg using the codec.
+func DecodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) DecodeRequestFunc[Req] {
+	return func(_ context.Context, msg *nats.Msg) (Req, error) {
+		return unmarshalCodecMsg(c, msg)
+	}
+}
+
+// EncodeResponseFuncFromCodec returns an EncodeResponseFunc which encodes the
+// response using the codec and publishes it to the subscriber reply.
+func EncodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) EncodeResponseFunc[Resp] {
+	return func(_ context.Context, reply string, nc *nats.Conn, response Resp) error {
+		b, err := c.Marshal(response)
+		if err != nil {
+			return err
+		}
+		return nc.Publish(reply, b)
+	}
+}
+
+// EncodeRequestFuncFromCodec returns an EncodeRequestFunc which encodes the
+// request to the Data of the Msg using the codec.
+func EncodeRequestFuncFromCodec[Req any](c codec.Codec[Req]) EncodeRequestFunc[Req] {
+	return func(_ context.Context, msg *nats.Msg, request Req) error {
+		b, err := c.Marshal(request)
+		if err != nil {
+			return err
+		}
+		msg.Data = b
+		return nil
+	}
+}
+
+// DecodeResponseFuncFromCodec returns a DecodeResponseFunc which decodes the
+// Data of the Msg using the codec.
+func DecodeResponseFuncFromCodec[Resp any](c codec.Codec[Resp]) DecodeResponseFunc[Resp] {
+	return func(_ context.Context, msg *nats.Msg) (Resp, error) {
+		return unmarshalCodecMsg(c, msg)
+	}
+}
+
+func unmarshalCodecMsg[T any](c codec.Codec[T], msg *nats.Msg) (T, error) {
+	var ret T
+	if err := c.Unmarshal(msg.Data, &ret); err != nil {
+		var zero T
+		return zero, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,38 @@
+package nats_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/codec"
+	natstransport "github.com/RangelReale/go-kit-typed/transport/nats"
+	"github.com/nats-io/nats.go"
+)
+
+func TestCodecRequest(t *testing.T) {
+	c := codec.XML[TestResponse]()
+
+	msg := &nats.Msg{}
+	if err := natstransport.EncodeRequestFuncFromCodec(c)(context.Background(), msg, TestResponse{String: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "<TestResponse><String>a</String><Error></Error></TestResponse>", string(msg.Data); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	req, err := natstransport.DecodeRequestFuncFromCodec(c)(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a", req.String; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	resp, err := natstransport.DecodeResponseFuncFromCodec(c)(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "a", resp.String; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
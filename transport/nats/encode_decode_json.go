@@ -0,0 +1,59 @@
+package nats
+
+import (
+	"context"
+	"io"
+
+	"github.com/RangelReale/go-kit-typed/util"
+	gokitnatstransport "github.com/go-kit/kit/transport/nats"
+	"github.com/nats-io/nats.go"
+)
+
+// EncodeJSONRequest is an EncodeRequestFunc that serializes the request as a
+// JSON object to the Data of the Msg.
+func EncodeJSONRequest[Req any](ctx context.Context, msg *nats.Msg, request Req) error {
+	return gokitnatstransport.EncodeJSONRequest(ctx, msg, request)
+}
+
+// EncodeJSONResponse is an EncodeResponseFunc that serializes the response as a
+// JSON object to the subscriber reply.
+func EncodeJSONResponse[Resp any](ctx context.Context, reply string, nc *nats.Conn, response Resp) error {
+	return gokitnatstransport.EncodeJSONResponse(ctx, reply, nc, response)
+}
+
+// DecodeJSONRequest is a DecodeRequestFunc that deserializes the JSON Data of
+// the Msg into the request type. Empty data returns the zero value.
+func DecodeJSONRequest[Req any](_ context.Context, msg *nats.Msg) (Req, error) {
+	return decodeJSONMsg[Req](msg, util.JSONOptions{})
+}
+
+// MakeDecodeJSONRequest returns a DecodeJSONRequest using the passed options.
+func MakeDecodeJSONRequest[Req any](options ...util.JSONOption) DecodeRequestFunc[Req] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, msg *nats.Msg) (Req, error) {
+		return decodeJSONMsg[Req](msg, opts)
+	}
+}
+
+// DecodeJSONResponse is a DecodeResponseFunc that deserializes the JSON Data
+// of the Msg into the response type. Empty data returns the zero value.
+func DecodeJSONResponse[Resp any](_ context.Context, msg *nats.Msg) (Resp, error) {
+	return decodeJSONMsg[Resp](msg, util.JSONOptions{})
+}
+
+// MakeDecodeJSONResponse returns a DecodeJSONResponse using the passed options.
+func MakeDecodeJSONResponse[Resp any](options ...util.JSONOption) DecodeResponseFunc[Resp] {
+	opts := util.NewJSONOptions(options...)
+	return func(_ context.Context, msg *nats.Msg) (Resp, error) {
+		return decodeJSONMsg[Resp](msg, opts)
+	}
+}
+
+func decodeJSONMsg[T any](msg *nats.Msg, options util.JSONOptions) (T, error) {
+	var ret T
+	if err := options.Unmarshal(msg.Data, &ret); err != nil && err != io.EOF {
+		var zero T
+		return zero, err
+	}
+	return ret, nil
+}
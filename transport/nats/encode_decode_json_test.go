@@ -0,0 +1,62 @@
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	natstransport "github.com/RangelReale/go-kit-typed/transport/nats"
+	"github.com/RangelReale/go-kit-typed/util"
+	"github.com/nats-io/nats.go"
+)
+
+func TestJSONPublisherSubscriber(t *testing.T) {
+	s, c := newNATSConn(t)
+	defer func() { s.Shutdown(); s.WaitForShutdown() }()
+	defer c.Close()
+
+	handler := natstransport.NewSubscriber(
+		func(_ context.Context, req TestResponse) (TestResponse, error) {
+			return TestResponse{String: req.String + "-resp"}, nil
+		},
+		natstransport.MakeDecodeJSONRequest[TestResponse](util.JSONDisallowUnknownFields()),
+		natstransport.EncodeJSONResponse[TestResponse],
+	)
+
+	sub, err := c.QueueSubscribe("natstransport.test", "natstransport", handler.ServeMsg(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	publisher := natstransport.NewPublisher(
+		c,
+		"natstransport.test",
+		natstransport.EncodeJSONRequest[TestResponse],
+		natstransport.DecodeJSONResponse[TestResponse],
+	)
+
+	response, err := publisher.Endpoint()(context.Background(), TestResponse{String: "req"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "req-resp", response.String; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestDecodeJSONMsg(t *testing.T) {
+	resp, err := natstransport.DecodeJSONResponse[TestResponse](context.Background(), &nats.Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (resp != TestResponse{}) {
+		t.Errorf("expected zero value, have %v", resp)
+	}
+
+	_, err = natstransport.MakeDecodeJSONRequest[TestResponse](util.JSONMaxBytes(4))(context.Background(),
+		&nats.Msg{Data: []byte(`{"str": "abc"}`)})
+	if !errors.Is(err, util.ErrMessageTooLarge) {
+		t.Errorf("expected ErrMessageTooLarge, received %v", err)
+	}
+}
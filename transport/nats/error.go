@@ -0,0 +1,49 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	"github.com/nats-io/nats.go"
+)
+
+// errorResponse is the error reply of gokitnatstransport.DefaultErrorEncoder.
+type errorResponse struct {
+	Error string `json:"err"`
+}
+
+// DefaultErrorEncoder publishes the error like
+// gokitnatstransport.DefaultErrorEncoder, as a JSON object with the message in
+// the "err" member, also sending its errkind kind in the Error-Kind header when
+// the connection supports headers.
+func DefaultErrorEncoder(_ context.Context, err error, reply string, nc *nats.Conn) {
+	b, merr := json.Marshal(errorResponse{Error: err.Error()})
+	if merr != nil {
+		return
+	}
+	msg := nats.NewMsg(reply)
+	msg.Data = b
+	if nc.HeadersSupported() {
+		msg.Header.Set(errkind.Header, string(errkind.KindOf(err)))
+	}
+	_ = nc.PublishMsg(msg)
+}
+
+// DecodeErrorKind returns a DecodeResponseFunc which returns the replies with
+// the Error-Kind header, sent by the DefaultErrorEncoder, as an error with
+// their errkind kind and message, and calls next for all other replies.
+func DecodeErrorKind[Resp any](next DecodeResponseFunc[Resp]) DecodeResponseFunc[Resp] {
+	return func(ctx context.Context, msg *nats.Msg) (Resp, error) {
+		kind := msg.Header.Get(errkind.Header)
+		if kind == "" {
+			return next(ctx, msg)
+		}
+		var resp errorResponse
+		if err := json.Unmarshal(msg.Data, &resp); err != nil {
+			resp.Error = string(msg.Data)
+		}
+		var ret Resp
+		return ret, errkind.New(errkind.Kind(kind), resp.Error)
+	}
+}
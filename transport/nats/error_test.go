@@ -0,0 +1,83 @@
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RangelReale/go-kit-typed/errkind"
+	natstransport "github.com/RangelReale/go-kit-typed/transport/nats"
+)
+
+func TestErrorKindRoundTrip(t *testing.T) {
+	s, c := newNATSConn(t)
+	defer func() { s.Shutdown(); s.WaitForShutdown() }()
+	defer c.Close()
+
+	handler := natstransport.NewSubscriber(
+		func(_ context.Context, req TestResponse) (TestResponse, error) {
+			if req.String == "" {
+				return TestResponse{}, errors.New("dang")
+			}
+			return TestResponse{}, errkind.New(errkind.Kind(req.String), "dang")
+		},
+		natstransport.DecodeJSONRequest[TestResponse],
+		natstransport.EncodeJSONResponse[TestResponse],
+	)
+	sub, err := c.QueueSubscribe("natstransport.test", "natstransport", handler.ServeMsg(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	client := natstransport.NewPublisher(c, "natstransport.test",
+		natstransport.EncodeJSONRequest[TestResponse],
+		natstransport.DecodeErrorKind(natstransport.DecodeJSONResponse[TestResponse]),
+	).Endpoint()
+
+	for _, kind := range []errkind.Kind{errkind.NotFound, errkind.PermissionDenied, errkind.Unknown} {
+		request := TestResponse{String: string(kind)}
+		if kind == errkind.Unknown {
+			request.String = ""
+		}
+		_, err := client(context.Background(), request)
+		if !errors.Is(err, kind) {
+			t.Errorf("want errors.Is %q, have %v", kind, err)
+		}
+		if want, have := "dang", err.Error(); want != have {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}
+}
+
+func TestErrorKindWithoutDecodeErrorKind(t *testing.T) {
+	s, c := newNATSConn(t)
+	defer func() { s.Shutdown(); s.WaitForShutdown() }()
+	defer c.Close()
+
+	handler := natstransport.NewSubscriber(
+		func(context.Context, TestResponse) (TestResponse, error) {
+			return TestResponse{}, errkind.New(errkind.NotFound, "dang")
+		},
+		natstransport.DecodeJSONRequest[TestResponse],
+		natstransport.EncodeJSONResponse[TestResponse],
+	)
+	sub, err := c.QueueSubscribe("natstransport.test", "natstransport", handler.ServeMsg(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	client := natstransport.NewPublisher(c, "natstransport.test",
+		natstransport.EncodeJSONRequest[TestResponse],
+		natstransport.DecodeJSONResponse[TestResponse],
+	).Endpoint()
+
+	resp, err := client(context.Background(), TestResponse{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "dang", resp.Error; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
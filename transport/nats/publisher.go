@@ -12,6 +12,9 @@ type Publisher[Req any, Resp any] struct {
 }
 
 // NewPublisher constructs a usable Publisher for a single remote method.
+// Replies are passed to the decoder as is; wrap it with DecodeErrorKind to
+// return the replies of the DefaultErrorEncoder as errors with their errkind
+// kind.
 func NewPublisher[Req any, Resp any](
 	publisher *nats.Conn,
 	subject string,
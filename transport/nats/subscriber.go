@@ -12,7 +12,9 @@ type Subscriber[Req any, Resp any] struct {
 }
 
 // NewSubscriber constructs a new subscriber, which provides nats.MsgHandler and wraps
-// the provided endpoint.
+// the provided endpoint. By default, errors are published with the
+// DefaultErrorEncoder, which sends the same reply as the Go kit one with the
+// addition of the Error-Kind header.
 func NewSubscriber[Req any, Resp any](
 	e endpoint.Endpoint[Req, Resp],
 	dec DecodeRequestFunc[Req],
@@ -23,7 +25,7 @@ func NewSubscriber[Req any, Resp any](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
+		subscriberOptions(options)...)
 	return &Subscriber[Req, Resp]{
 		subscriber: subscriber,
 	}
@@ -41,7 +43,7 @@ func NewSubscriberStdDec[Req any, Resp any](
 		endpoint.ReverseAdapter(e),
 		dec,
 		EncodeResponseFuncReverseAdapter(enc),
-		options...)
+		subscriberOptions(options)...)
 	return &Subscriber[Req, Resp]{
 		subscriber: subscriber,
 	}
@@ -59,12 +61,20 @@ func NewSubscriberStdEnc[Req any, Resp any](
 		endpoint.ReverseAdapter(e),
 		DecodeRequestFuncReverseAdapter(dec),
 		enc,
-		options...)
+		subscriberOptions(options)...)
 	return &Subscriber[Req, Resp]{
 		subscriber: subscriber,
 	}
 }
 
+// subscriberOptions returns the options with the DefaultErrorEncoder, which
+// the passed options may override.
+func subscriberOptions(options []gokitnatstransport.SubscriberOption) []gokitnatstransport.SubscriberOption {
+	return append([]gokitnatstransport.SubscriberOption{
+		gokitnatstransport.SubscriberErrorEncoder(DefaultErrorEncoder),
+	}, options...)
+}
+
 // ServeMsg provides nats.MsgHandler.
 func (s Subscriber[Req, Resp]) ServeMsg(nc *nats.Conn) func(msg *nats.Msg) {
 	return s.subscriber.ServeMsg(nc)
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var (
+	ErrMessageTooLarge = errors.New("message too large")
+)
+
+// JSONOptions are the options used to decode JSON data.
+type JSONOptions struct {
+	// DisallowUnknownFields causes an error when the destination is a struct
+	// and the input contains object keys which do not match any field.
+	DisallowUnknownFields bool
+	// UseNumber causes numbers to be decoded into an interface{} as a
+	// json.Number instead of as a float64.
+	UseNumber bool
+	// MaxBytes is the maximum size of the input, 0 means unlimited.
+	MaxBytes int64
+}
+
+// JSONOption sets an optional parameter for JSON decoding.
+type JSONOption func(*JSONOptions)
+
+// JSONDisallowUnknownFields enables strict decoding, returning an error for
+// object keys which do not match any field of the destination struct.
+func JSONDisallowUnknownFields() JSONOption {
+	return func(o *JSONOptions) { o.DisallowUnknownFields = true }
+}
+
+// JSONUseNumber decodes numbers into an interface{} as json.Number.
+func JSONUseNumber() JSONOption {
+	return func(o *JSONOptions) { o.UseNumber = true }
+}
+
+// JSONMaxBytes limits the size of the input. Larger inputs return
+// ErrMessageTooLarge.
+func JSONMaxBytes(n int64) JSONOption {
+	return func(o *JSONOptions) { o.MaxBytes = n }
+}
+
+// NewJSONOptions returns the JSONOptions with the passed options applied.
+func NewJSONOptions(options ...JSONOption) JSONOptions {
+	var ret JSONOptions
+	for _, option := range options {
+		option(&ret)
+	}
+	return ret
+}
+
+// Decode decodes the next JSON value of r into v. If r has no data,
+// io.EOF is returned.
+func (o JSONOptions) Decode(r io.Reader, v interface{}) error {
+	if o.MaxBytes > 0 {
+		r = &maxBytesReader{r: r, remaining: o.MaxBytes}
+	}
+	dec := json.NewDecoder(r)
+	if o.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if o.UseNumber {
+		dec.UseNumber()
+	}
+	err := dec.Decode(v)
+	if err != nil && errors.Is(err, ErrMessageTooLarge) {
+		return ErrMessageTooLarge
+	}
+	return err
+}
+
+// Unmarshal decodes the JSON data into v. If data is empty, io.EOF is
+// returned.
+func (o JSONOptions) Unmarshal(data []byte, v interface{}) error {
+	if o.MaxBytes > 0 && int64(len(data)) > o.MaxBytes {
+		return ErrMessageTooLarge
+	}
+	return o.Decode(bytes.NewReader(data), v)
+}
+
+// maxBytesReader returns ErrMessageTooLarge when more than the allowed
+// number of bytes is read.
+type maxBytesReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.remaining < 0 {
+		return 0, ErrMessageTooLarge
+	}
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		return n + int(m.remaining), ErrMessageTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONOptions(t *testing.T) {
+	type data struct {
+		A int `json:"a"`
+	}
+
+	tests := []struct {
+		input    string
+		options  []JSONOption
+		expected data
+		error    error
+		anyError bool
+	}{
+		{
+			input:    `{"a": 1}`,
+			expected: data{A: 1},
+		},
+		{
+			input:    `{"a": 1, "b": 2}`,
+			expected: data{A: 1},
+		},
+		{
+			input:    `{"a": 1, "b": 2}`,
+			options:  []JSONOption{JSONDisallowUnknownFields()},
+			anyError: true,
+		},
+		{
+			input:    `{"a": 1}`,
+			options:  []JSONOption{JSONMaxBytes(8)},
+			expected: data{A: 1},
+		},
+		{
+			input:   `{"a": 12345}`,
+			options: []JSONOption{JSONMaxBytes(8)},
+			error:   ErrMessageTooLarge,
+		},
+		{
+			input: ``,
+			error: io.EOF,
+		},
+	}
+
+	for _, test := range tests {
+		for _, unmarshal := range []bool{false, true} {
+			var have data
+			var err error
+			if unmarshal {
+				err = NewJSONOptions(test.options...).Unmarshal([]byte(test.input), &have)
+			} else {
+				err = NewJSONOptions(test.options...).Decode(strings.NewReader(test.input), &have)
+			}
+			if test.error != nil || test.anyError {
+				if err == nil || (test.error != nil && !errors.Is(err, test.error)) {
+					t.Fatalf("%s: expected '%v' got '%v'", test.input, test.error, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != test.expected {
+					t.Errorf("want %v, have %v", test.expected, have)
+				}
+			}
+		}
+	}
+}
+
+func TestJSONUseNumber(t *testing.T) {
+	var v interface{}
+	if err := NewJSONOptions(JSONUseNumber()).Unmarshal([]byte(`12345678901234567890`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := json.Number("12345678901234567890"), v; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}